@@ -0,0 +1,195 @@
+package gogger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy enumeration type for the behavior of a full asynchronous queue
+type OverflowPolicy int
+
+const (
+	// Block waits until the queue has room for the record
+	Block OverflowPolicy = iota
+	// DropNewest discards the record being logged
+	DropNewest
+	// DropOldest discards the oldest queued record to make room
+	DropOldest
+	// DropBelowLevel discards records below the drop level and blocks for the rest
+	DropBelowLevel
+)
+
+// asyncQueue is a bounded queue of records drained by a background goroutine
+type asyncQueue struct {
+	records   chan *Record
+	policy    OverflowPolicy
+	dropLevel *atomic.Int64
+	dropped   *atomic.Uint64
+	dispatch  func(record *Record)
+	done      chan struct{}
+
+	closeMu sync.RWMutex
+	closed  bool
+
+	pendingMu   sync.Mutex
+	pendingCond *sync.Cond
+	pending     int
+}
+
+func newAsyncQueue(queueSize int, policy OverflowPolicy, dropLevel *atomic.Int64, dropped *atomic.Uint64, dispatch func(record *Record)) *asyncQueue {
+	q := &asyncQueue{
+		records:   make(chan *Record, queueSize),
+		policy:    policy,
+		dropLevel: dropLevel,
+		dropped:   dropped,
+		dispatch:  dispatch,
+		done:      make(chan struct{}),
+	}
+	q.pendingCond = sync.NewCond(&q.pendingMu)
+
+	go q.run()
+
+	return q
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	for record := range q.records {
+		q.dispatch(record)
+		q.release()
+	}
+}
+
+// enqueue adds the record according to the overflow policy.
+// It returns false when the queue is already closed
+func (q *asyncQueue) enqueue(record *Record) bool {
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	q.pendingMu.Lock()
+	q.pending++
+	q.pendingMu.Unlock()
+
+	switch q.policy {
+	case DropNewest:
+		q.tryEnqueue(record)
+	case DropOldest:
+		for {
+			select {
+			case q.records <- record:
+				return true
+			default:
+			}
+
+			select {
+			case <-q.records:
+				q.drop()
+			default:
+			}
+		}
+	case DropBelowLevel:
+		if int64(record.Level) < q.dropLevel.Load() {
+			q.tryEnqueue(record)
+		} else {
+			q.records <- record
+		}
+	default: // Block
+		q.records <- record
+	}
+
+	return true
+}
+
+// tryEnqueue adds the record without blocking, dropping it when the queue is full
+func (q *asyncQueue) tryEnqueue(record *Record) {
+	select {
+	case q.records <- record:
+	default:
+		q.drop()
+	}
+}
+
+func (q *asyncQueue) drop() {
+	q.dropped.Add(1)
+	q.release()
+}
+
+func (q *asyncQueue) release() {
+	q.pendingMu.Lock()
+	defer q.pendingMu.Unlock()
+
+	q.pending--
+	if q.pending == 0 {
+		q.pendingCond.Broadcast()
+	}
+}
+
+// wait blocks until every queued record has been dispatched or dropped
+func (q *asyncQueue) wait() {
+	q.pendingMu.Lock()
+	defer q.pendingMu.Unlock()
+
+	for q.pending > 0 {
+		q.pendingCond.Wait()
+	}
+}
+
+// close stops accepting records and waits until the queue is drained
+func (q *asyncQueue) close() {
+	q.closeMu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.records)
+	}
+	q.closeMu.Unlock()
+
+	<-q.done
+}
+
+// SetAsync switches the logger to asynchronous mode where records are queued
+// and written by a background goroutine. A queueSize of 0 drains the queue and
+// returns to synchronous writing
+func (l *Gogger) SetAsync(queueSize int, policy OverflowPolicy) error {
+	if queueSize < 0 || policy < Block || policy > DropBelowLevel {
+		return fmt.Errorf("invalid queue size or overflow policy")
+	}
+
+	var q *asyncQueue
+	if queueSize > 0 {
+		q = newAsyncQueue(queueSize, policy, &l.asyncDropLevel, &l.dropped, l.dispatch)
+	}
+
+	l.mu.Lock()
+	old := l.async
+	l.async = q
+	l.mu.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+
+	return nil
+}
+
+// SetAsyncDropLevel sets the level below which records are dropped by the DropBelowLevel policy, INFO by default
+func (l *Gogger) SetAsyncDropLevel(level LogLevel) {
+	l.asyncDropLevel.Store(int64(level))
+}
+
+// DroppedRecords returns the number of records dropped because the asynchronous queue was full
+func (l *Gogger) DroppedRecords() uint64 {
+	return l.dropped.Load()
+}
+
+func (l *Gogger) getAsyncQueue() *asyncQueue {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.async
+}
@@ -0,0 +1,165 @@
+package gogger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// blockingSink records messages and blocks every write until release is closed
+type blockingSink struct {
+	sinkBase
+	release  chan struct{}
+	started  chan struct{}
+	once     sync.Once
+	messages []string
+}
+
+func newBlockingSink() *blockingSink {
+	return &blockingSink{release: make(chan struct{}), started: make(chan struct{})}
+}
+
+func (s *blockingSink) Write(record *Record) error {
+	s.once.Do(func() { close(s.started) })
+	<-s.release
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, record.Message)
+	return nil
+}
+
+func (s *blockingSink) Flush() error { return nil }
+
+func (s *blockingSink) Close() error { return nil }
+
+func TestAsyncFlushAndClose(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	Logger.SetUseConsoleLog(false)
+
+	if err := Logger.SetAsync(16, Block); err != nil {
+		t.Fatalf("SetAsync returned unexpected error: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		Logger.Info("Async message")
+	}
+	Logger.Flush()
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if count := strings.Count(string(content), "Async message"); count != 50 {
+		t.Errorf("Expected 50 messages after Flush, got %d", count)
+	}
+
+	for i := 0; i < 10; i++ {
+		Logger.Info("Drained on close")
+	}
+	Logger.Close()
+
+	content, err = os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if count := strings.Count(string(content), "Drained on close"); count != 10 {
+		t.Errorf("Expected 10 messages drained on Close, got %d", count)
+	}
+
+	if Logger.DroppedRecords() != 0 {
+		t.Errorf("Expected no dropped records with Block policy, got %d", Logger.DroppedRecords())
+	}
+}
+
+func TestAsyncOverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       OverflowPolicy
+		levels       []LogLevel
+		wantMessages []string
+		wantDropped  uint64
+	}{
+		{
+			name:         "Drop newest",
+			policy:       DropNewest,
+			levels:       []LogLevel{INFO, INFO, INFO, INFO},
+			wantMessages: []string{"0", "1", "2"},
+			wantDropped:  1,
+		},
+		{
+			name:         "Drop oldest",
+			policy:       DropOldest,
+			levels:       []LogLevel{INFO, INFO, INFO, INFO},
+			wantMessages: []string{"0", "2", "3"},
+			wantDropped:  1,
+		},
+		{
+			name:         "Drop below level",
+			policy:       DropBelowLevel,
+			levels:       []LogLevel{INFO, INFO, INFO, DEBUG},
+			wantMessages: []string{"0", "1", "2"},
+			wantDropped:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newBlockingSink()
+			sink.SetLevel(DEBUG)
+			logger := newTestLogger(t, sink)
+
+			if err := logger.SetAsync(2, tt.policy); err != nil {
+				t.Fatalf("SetAsync returned unexpected error: %v", err)
+			}
+
+			// The first record is taken by the background goroutine which then blocks in the sink
+			logger.Log(tt.levels[0], "0")
+			<-sink.started
+			for i, level := range tt.levels[1:] {
+				logger.Log(level, string(rune('1'+i)))
+			}
+
+			close(sink.release)
+			logger.Close()
+
+			if strings.Join(sink.messages, ",") != strings.Join(tt.wantMessages, ",") {
+				t.Errorf("Expected messages %v, got %v", tt.wantMessages, sink.messages)
+			}
+			if logger.DroppedRecords() != tt.wantDropped {
+				t.Errorf("Expected %d dropped records, got %d", tt.wantDropped, logger.DroppedRecords())
+			}
+		})
+	}
+}
+
+func TestSetAsyncDisable(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(t, NewWriterSink(&buf))
+	defer logger.Close()
+
+	if err := logger.SetAsync(-1, Block); err == nil {
+		t.Error("SetAsync should return an error for a negative queue size")
+	}
+
+	if err := logger.SetAsync(8, DropNewest); err != nil {
+		t.Fatalf("SetAsync returned unexpected error: %v", err)
+	}
+	logger.Info("Queued message")
+
+	if err := logger.SetAsync(0, Block); err != nil {
+		t.Fatalf("SetAsync returned unexpected error: %v", err)
+	}
+	if logger.getAsyncQueue() != nil {
+		t.Error("Expected asynchronous mode to be disabled")
+	}
+
+	logger.Info("Synchronous message")
+	if !strings.Contains(buf.String(), "Queued message\n") || !strings.Contains(buf.String(), "Synchronous message\n") {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
@@ -0,0 +1,112 @@
+package gogger
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// callerDepth is the number of frames between captureCaller and the code calling a logging method:
+// captureCaller, write, log or logf and the logging method itself
+const callerDepth = 4
+
+// bridgeSkip asks captureCaller to find the caller of a LineWriter instead of using a fixed depth
+const bridgeSkip = -1
+
+// bridgePackages are the packages whose frames are skipped when a LineWriter looks for its caller
+var bridgePackages = []string{"log.", "fmt.", "io.", "bufio."}
+
+var packagePath = reflect.TypeOf(Gogger{}).PkgPath()
+
+// Caller is the source location of the code that produced a record
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// ShortFile returns the file name without its directory
+func (c Caller) ShortFile() string {
+	if c.File == "" {
+		return ""
+	}
+	return filepath.Base(c.File)
+}
+
+// ShortFunction returns the function name without its package path
+func (c Caller) ShortFunction() string {
+	if i := strings.LastIndex(c.Function, "/"); i >= 0 {
+		return c.Function[i+1:]
+	}
+	return c.Function
+}
+
+// CallerRenderer is implemented by formatters and sinks that can tell whether they render the caller.
+// A custom Sink returning true from NeedsCaller receives records with Caller set
+type CallerRenderer interface {
+	NeedsCaller() bool
+}
+
+// WithCallerSkip returns a child logger reporting the caller skip frames further up the stack.
+// Use it in helpers wrapping the logging methods so records point at the code calling the helper
+func (l *Gogger) WithCallerSkip(skip int) *Gogger {
+	return &Gogger{loggerCore: l.loggerCore, name: l.name, fields: l.fields, callerSkip: l.callerSkip + skip}
+}
+
+// needsCaller reports whether at least one of the sinks renders the caller
+func needsCaller(sinks []Sink) bool {
+	for _, sink := range sinks {
+		if s, ok := sink.(CallerRenderer); ok && s.NeedsCaller() {
+			return true
+		}
+	}
+	return false
+}
+
+// captureCaller returns the caller skip frames above the logging method, or the caller of a LineWriter for bridgeSkip
+func captureCaller(skip int) Caller {
+	if skip == bridgeSkip {
+		return bridgeCaller()
+	}
+
+	pc := make([]uintptr, 1)
+	if runtime.Callers(callerDepth+skip+1, pc) == 0 {
+		return Caller{}
+	}
+	return callerFromPC(pc[0])
+}
+
+// bridgeCaller returns the first frame outside the LineWriter and the packages writing to it
+func bridgeCaller() Caller {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !isBridgeFrame(frame.Function) {
+			return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+		}
+		if !more {
+			return Caller{}
+		}
+	}
+}
+
+func isBridgeFrame(function string) bool {
+	if strings.HasPrefix(function, packagePath+".(*LineWriter)") || strings.HasPrefix(function, packagePath+".(*Gogger).write") {
+		return true
+	}
+	for _, pkg := range bridgePackages {
+		if strings.HasPrefix(function, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+func callerFromPC(pc uintptr) Caller {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+}
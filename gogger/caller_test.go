@@ -0,0 +1,184 @@
+package gogger
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// nextLine returns the line following the call
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line + 1
+}
+
+// logHelper is a wrapper reporting the code calling it as the caller
+func logHelper(l *Gogger, message string) {
+	l.WithCallerSkip(1).Warning(message)
+}
+
+func TestCallerPlaceholders(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%file%:%line% %func% %message%", DEBUG)
+
+	tests := []struct {
+		name string
+		log  func() int
+	}{
+		{"Info", func() int {
+			line := nextLine()
+			logger.Info("message")
+			return line
+		}},
+		{"Infof", func() int {
+			line := nextLine()
+			logger.Infof("message %d", 1)
+			return line
+		}},
+		{"Log", func() int {
+			line := nextLine()
+			logger.Log(INFO, "message")
+			return line
+		}},
+		{"InfoW", func() int {
+			line := nextLine()
+			logger.InfoW("message", "key", "value")
+			return line
+		}},
+		{"With", func() int {
+			line := nextLine()
+			logger.With("key", "value").Named("child").Debug("message")
+			return line
+		}},
+		{"WithCallerSkip", func() int {
+			line := nextLine()
+			logHelper(logger, "message")
+			return line
+		}},
+		{"Writer", func() int {
+			line := nextLine()
+			fmt.Fprintln(logger.Writer(INFO), "message")
+			return line
+		}},
+		{"StdLogger", func() int {
+			line := nextLine()
+			logger.StdLogger(INFO).Println("message")
+			return line
+		}},
+		{"SlogHandler", func() int {
+			line := nextLine()
+			slog.New(NewSlogHandler(logger)).Info("message")
+			return line
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			line := tt.log()
+
+			output := buf.String()
+			prefix := fmt.Sprintf("caller_test.go:%d gogger.TestCallerPlaceholders.func", line)
+			if !strings.HasPrefix(output, prefix) {
+				t.Errorf("Expected output to start with %q, got %q", prefix, output)
+			}
+		})
+	}
+}
+
+func TestRedirectStdLogCaller(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%file%:%line% %message%", DEBUG)
+
+	restore := logger.RedirectStdLog(INFO)
+	defer restore()
+
+	line := nextLine()
+	log.Print("message")
+
+	expected := fmt.Sprintf("caller_test.go:%d message\n", line)
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+// callerRecordingSink keeps the records it receives
+type callerRecordingSink struct {
+	sinkBase
+	records []*Record
+}
+
+func (s *callerRecordingSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *callerRecordingSink) Flush() error { return nil }
+
+func (s *callerRecordingSink) Close() error { return nil }
+
+func TestCallerOnlyCapturedWhenUsed(t *testing.T) {
+	sink := &callerRecordingSink{}
+	logger := newTestLogger(t, sink)
+	defer logger.Close()
+
+	logger.Info("without caller")
+
+	formatter, err := NewTextFormatter("%line% %message%")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	sink.SetFormatter(formatter)
+	logger.Info("with caller")
+
+	if len(sink.records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(sink.records))
+	}
+	if sink.records[0].Caller != (Caller{}) {
+		t.Errorf("Expected no caller, got %+v", sink.records[0].Caller)
+	}
+	if sink.records[1].Caller.Line == 0 {
+		t.Error("Expected the caller to be captured")
+	}
+}
+
+// customCallerSink is a sink written outside the package that asks for the caller through CallerRenderer
+type customCallerSink struct {
+	records []*Record
+}
+
+func (s *customCallerSink) Write(record *Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *customCallerSink) Flush() error { return nil }
+
+func (s *customCallerSink) Close() error { return nil }
+
+func (s *customCallerSink) Level() LogLevel { return DEBUG }
+
+func (s *customCallerSink) SetLevel(level LogLevel) {}
+
+func (s *customCallerSink) SetFormatter(formatter Formatter) {}
+
+func (s *customCallerSink) NeedsCaller() bool { return true }
+
+func TestCallerRendererCustomSink(t *testing.T) {
+	sink := &customCallerSink{}
+	logger := newTestLogger(t, sink)
+	defer logger.Close()
+
+	line := nextLine()
+	logger.Info("message")
+
+	if len(sink.records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(sink.records))
+	}
+	if got := sink.records[0].Caller; got.ShortFile() != "caller_test.go" || got.Line != line {
+		t.Errorf("Expected caller_test.go:%d, got %s:%d", line, got.ShortFile(), got.Line)
+	}
+}
@@ -0,0 +1,71 @@
+package gogger
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time to the logger, replace it with a FakeClock in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// Now returns the current local time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock that only moves when told to. It is safe for concurrent use
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock stopped at the time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the duration
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to the time
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// SetClock sets the clock used for timestamps, time-based rotation and retention of the file.
+// A nil clock restores the system clock
+func (l *Gogger) SetClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	l.mu.Lock()
+	l.clock = clock
+	l.mu.Unlock()
+
+	l.fileSink.SetClock(clock)
+}
+
+// now returns the time of the logger clock
+func (l *Gogger) now() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.clock.Now()
+}
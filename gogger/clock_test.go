@@ -0,0 +1,85 @@
+package gogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 9, 30, 21, 59, 5, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	clock.Advance(time.Minute)
+	if got := clock.Now(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected %v after Advance, got %v", start.Add(time.Minute), got)
+	}
+
+	clock.Set(start)
+	if got := clock.Now(); !got.Equal(start) {
+		t.Errorf("Expected %v after Set, got %v", start, got)
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	tempDir := t.TempDir()
+	logger, err := NewGogger("test.log", tempDir, 100, 10)
+	if err != nil {
+		t.Fatalf("Failed to create Gogger instance: %v", err)
+	}
+	defer logger.Close()
+
+	clock := NewFakeClock(time.Date(2020, 9, 29, 23, 0, 0, 0, time.Local))
+	logger.SetClock(clock)
+	logger.SetUseConsoleLog(false)
+	if err := logger.SetLogFormat("[%timestamp%] %message%"); err != nil {
+		t.Fatalf("SetLogFormat returned unexpected error: %v", err)
+	}
+
+	policy, err := RotateDaily(0, 0)
+	if err != nil {
+		t.Fatalf("RotateDaily returned unexpected error: %v", err)
+	}
+	if err := logger.SetRotationPolicy(policy); err != nil {
+		t.Fatalf("SetRotationPolicy returned unexpected error: %v", err)
+	}
+
+	logger.Info("first")
+	clock.Advance(90 * time.Minute)
+	logger.Info("second")
+
+	expected := map[string]string{
+		"#0_2020-09-29_test.log": "[29-09-2020 23:00:00] first\n",
+		"#1_2020-09-30_test.log": "[30-09-2020 00:30:00] second\n",
+	}
+	for name, want := range expected {
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read log file %s: %v", name, err)
+		}
+		if string(content) != want {
+			t.Errorf("Expected %s to contain %q, got %q", name, want, content)
+		}
+	}
+}
+
+func TestFileSinkClockRetention(t *testing.T) {
+	tempDir := t.TempDir()
+	createTestFile(t, tempDir, "#1test.log")
+
+	sink := newTestFileSink(t, tempDir, 100, 10)
+	clock := NewFakeClock(time.Now())
+	sink.SetClock(clock)
+
+	sink.SetMaxAge(24 * time.Hour)
+	if _, err := os.Stat(filepath.Join(tempDir, "#1test.log")); err != nil {
+		t.Fatalf("Expected #1test.log to be kept before the clock advances: %v", err)
+	}
+
+	clock.Advance(48 * time.Hour)
+	sink.SetMaxAge(24 * time.Hour)
+	if _, err := os.Stat(filepath.Join(tempDir, "#1test.log")); !os.IsNotExist(err) {
+		t.Errorf("Expected #1test.log to be deleted after the clock advances, got %v", err)
+	}
+}
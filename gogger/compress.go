@@ -0,0 +1,99 @@
+package gogger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Compression enumeration type for compression of rotated log files.
+// Only gzip is provided so the module stays free of third-party dependencies
+type Compression int
+
+const (
+	NoCompression Compression = iota
+	Gzip
+)
+
+// compressedExtension returns the file extension added by the compression
+func (c Compression) compressedExtension() string {
+	if c == Gzip {
+		return ".gz"
+	}
+	return ""
+}
+
+// compressFile compresses a closed segment in the background and replaces it
+// in the retention queue, so compressed segments still count toward maxFiles
+func (s *FileSink) compressFile(filePath string, compression Compression) {
+	defer s.compressWG.Done()
+
+	compressedPath := filePath + compression.compressedExtension()
+	if err := gzipFile(filePath, compressedPath); err != nil {
+		fmt.Printf("Error compressing file: %v\n", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if filePath != s.currentPath {
+		for i, file := range s.logQueueFiles {
+			if file == filePath {
+				s.logQueueFiles[i] = compressedPath
+				if err := os.Remove(filePath); err != nil {
+					fmt.Printf("Error deleting file: %v\n", err)
+				}
+				return
+			}
+		}
+	}
+
+	// The segment was deleted by retention or reopened while it was compressed
+	_ = os.Remove(compressedPath)
+}
+
+// gzipFile compresses the file into a new file, an existing destination is never overwritten
+func gzipFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func(src *os.File) {
+		_ = src.Close()
+	}(src)
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(dst)
+	writer.Name = filepath.Base(srcPath)
+	writer.ModTime = info.ModTime()
+
+	if _, err := io.Copy(writer, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(dstPath)
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(dstPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(dstPath)
+		return err
+	}
+
+	// Keep the modification time of the segment for age-based retention
+	return os.Chtimes(dstPath, info.ModTime(), info.ModTime())
+}
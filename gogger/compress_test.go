@@ -0,0 +1,150 @@
+package gogger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readGzipFile(t *testing.T, path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open compressed file: %v", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Failed to read gzip header: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress file: %v", err)
+	}
+	return string(content)
+}
+
+func TestFileSinkCompression(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newTestFileSink(t, tempDir, 2, 5)
+
+	if err := sink.SetCompression(Compression(42)); err == nil {
+		t.Error("SetCompression should return an error for an unknown compression")
+	}
+	if err := sink.SetCompression(Gzip); err != nil {
+		t.Fatalf("SetCompression returned unexpected error: %v", err)
+	}
+
+	for _, message := range []string{"a", "b", "c", "d", "e"} {
+		if err := sink.Write(&Record{Level: INFO, Message: message}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close returned unexpected error: %v", err)
+	}
+
+	if content := readGzipFile(t, filepath.Join(tempDir, "#0test.log.gz")); content != "a\nb\n" {
+		t.Errorf("Unexpected content of the first segment: %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "#0test.log")); !os.IsNotExist(err) {
+		t.Error("Expected the uncompressed segment to be removed")
+	}
+
+	for _, file := range sink.logQueueFiles[:len(sink.logQueueFiles)-1] {
+		if !strings.HasSuffix(file, ".gz") {
+			t.Errorf("Expected rotated segment %s to be compressed in the queue", file)
+		}
+	}
+	if current := sink.logQueueFiles[len(sink.logQueueFiles)-1]; strings.HasSuffix(current, ".gz") {
+		t.Errorf("Current segment %s must not be compressed", current)
+	}
+}
+
+func TestFileSinkCompressedRetention(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"#0test.log.gz", "#1test.log.gz"} {
+		createTestFile(t, tempDir, name)
+	}
+
+	sink := newTestFileSink(t, tempDir, 1, 3)
+	if err := sink.SetCompression(Gzip); err != nil {
+		t.Fatalf("SetCompression returned unexpected error: %v", err)
+	}
+
+	found := 0
+	for _, file := range sink.logQueueFiles {
+		if strings.HasSuffix(file, ".gz") {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("Expected 2 compressed segments in the queue, got %d", found)
+	}
+
+	for i := 0; i < 6; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: "entry"}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close returned unexpected error: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(tempDir, "#*test.log*"))
+	if err != nil {
+		t.Fatalf("Failed to list log files: %v", err)
+	}
+	if len(files) > 3 {
+		t.Errorf("Expected at most 3 segments including compressed ones, got %v", files)
+	}
+}
+
+func TestFileSinkCompressionAfterRestart(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for run, messages := range [][]string{{"a", "b", "c", "d", "e"}, {"f", "g", "h", "i", "j"}} {
+		sink := newTestFileSink(t, tempDir, 2, 10)
+		if err := sink.SetCompression(Gzip); err != nil {
+			t.Fatalf("SetCompression returned unexpected error: %v", err)
+		}
+		for _, message := range messages {
+			if err := sink.Write(&Record{Level: INFO, Message: message}); err != nil {
+				t.Fatalf("Write returned unexpected error: %v", err)
+			}
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close returned unexpected error: %v", err)
+		}
+
+		seen := make(map[string]bool)
+		for _, file := range sink.logQueueFiles {
+			if seen[file] {
+				t.Errorf("Run %d: file %s is listed twice in the queue %v", run, file, sink.logQueueFiles)
+			}
+			seen[file] = true
+		}
+	}
+
+	var content strings.Builder
+	for _, name := range logFileNames(t, tempDir) {
+		if strings.HasSuffix(name, ".gz") {
+			content.WriteString(readGzipFile(t, filepath.Join(tempDir, name)))
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read log file %s: %v", name, err)
+		}
+		content.Write(data)
+	}
+
+	for _, message := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		if !strings.Contains(content.String(), message+"\n") {
+			t.Errorf("Record %q of an earlier segment was overwritten, files %v", message, logFileNames(t, tempDir))
+		}
+	}
+}
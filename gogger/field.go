@@ -0,0 +1,100 @@
+package gogger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// badKey is used for values that were passed without a matching string key
+const badKey = "!BADKEY"
+
+// Field is a typed key/value pair attached to a log record
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F creates a new Field
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String returns the field rendered as key=value
+func (f Field) String() string {
+	return f.Key + "=" + formatFieldValue(f.Value)
+}
+
+// fieldsFromKeysAndValues converts alternating keys and values into fields.
+// A Field argument is taken as is, a value without a string key is stored under badKey.
+func fieldsFromKeysAndValues(keysAndValues []any) []Field {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i++ {
+		switch v := keysAndValues[i].(type) {
+		case Field:
+			fields = append(fields, v)
+		case string:
+			if i+1 < len(keysAndValues) {
+				fields = append(fields, Field{Key: v, Value: keysAndValues[i+1]})
+				i++
+			} else {
+				fields = append(fields, Field{Key: badKey, Value: v})
+			}
+		default:
+			fields = append(fields, Field{Key: badKey, Value: v})
+		}
+	}
+
+	return fields
+}
+
+// formatFields renders fields as space separated key=value pairs
+func formatFields(fields []Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(field.String())
+	}
+
+	return sb.String()
+}
+
+func formatFieldValue(value any) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if needsQuoting(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// needsQuoting reports whether a value must be quoted to stay a single key=value token
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
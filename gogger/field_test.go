@@ -0,0 +1,88 @@
+package gogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldsFromKeysAndValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		keysAndValues []any
+		want          []Field
+	}{
+		{"Empty", nil, nil},
+		{"Pairs", []any{"user", 42, "ok", true}, []Field{{"user", 42}, {"ok", true}}},
+		{"Field argument", []any{F("request", "abc"), "user", 1}, []Field{{"request", "abc"}, {"user", 1}}},
+		{"Missing value", []any{"user"}, []Field{{badKey, "user"}}},
+		{"Non-string key", []any{1, "user", 2}, []Field{{badKey, 1}, {"user", 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldsFromKeysAndValues(tt.keysAndValues)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d fields, got %d", len(tt.want), len(got))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Field %d: expected %v, got %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+		want   string
+	}{
+		{"No fields", nil, ""},
+		{"Simple values", []Field{F("user", 42), F("latency", 1500*time.Millisecond)}, "user=42 latency=1.5s"},
+		{"Quoted value", []Field{F("msg", "hello world")}, `msg="hello world"`},
+		{"Empty value", []Field{F("msg", "")}, `msg=""`},
+		{"Error value", []Field{F("err", errors.New("failed"))}, "err=failed"},
+		{"Control characters", []Field{F("msg", "\x1b[31mred")}, `msg="\x1b[31mred"`},
+		{"Unicode value", []Field{F("city", "Москва")}, "city=Москва"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatFields(tt.fields); got != tt.want {
+				t.Errorf("formatFields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogWithFields(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	defer Logger.Close()
+
+	if err := Logger.SetLogFormat("[%level%] %message% %fields%"); err != nil {
+		t.Fatalf("SetLogFormat returned unexpected error: %v", err)
+	}
+
+	Logger.InfoW("Request handled", "request_id", "abc", "status", 200)
+	Logger.LogW(ERROR, "Request failed", F("user", 7))
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if lines[0] != "[INFO] Request handled request_id=abc status=200" {
+		t.Errorf("Unexpected first line: %q", lines[0])
+	}
+	if lines[1] != "[ERROR] Request failed user=7" {
+		t.Errorf("Unexpected second line: %q", lines[1])
+	}
+}
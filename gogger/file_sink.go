@@ -0,0 +1,559 @@
+package gogger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileSink writes records to numbered files #N<filename> rotated by the number of entries, size and time.
+// It is safe for concurrent use
+type FileSink struct {
+	sinkBase
+	filename          string
+	fileStream        *os.File
+	pathFolder        string
+	logQueueFiles     []string
+	maxEntries        int
+	maxEntriesCounter int
+	maxFiles          int
+	logFileNumber     int
+	maxBytes          int64
+	currentSize       int64
+	rotation          RotationPolicy
+	period            string
+	periodStart       time.Time
+	currentPath       string
+	compression       Compression
+	compressWG        sync.WaitGroup
+	maxAge            time.Duration
+	clock             Clock
+	closed            bool
+}
+
+// NewFileSink creates a rotating file sink and opens its current file
+func NewFileSink(filename, pathFolder string, maxEntries, maxFiles int) (*FileSink, error) {
+
+	if !isValidFilename(filename) || !isValidPathFolder(pathFolder) || maxEntries <= 0 {
+		return nil, fmt.Errorf("invalid filename, path folder, or max_entries")
+	}
+
+	s := &FileSink{
+		sinkBase: sinkBase{
+			level:     INFO,
+			formatter: newDefaultTextFormatter(),
+		},
+		filename:          filename,
+		pathFolder:        pathFolder,
+		maxEntries:        maxEntries,
+		maxEntriesCounter: maxEntries,
+		maxFiles:          maxFiles,
+	}
+
+	if err := s.createFolder(); err != nil {
+		return nil, err
+	}
+
+	s.addCurrentFiles()
+	s.openFile()
+
+	return s, nil
+}
+
+// Write outputs the formatted record, rotating to the next file when the current one is full
+func (s *FileSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Records may arrive slightly out of order, only a later period starts a new file
+	if start := s.rotation.periodStart(record.Time); start.After(s.periodStart) {
+		if err := s.startPeriod(s.rotation.periodName(record.Time), start); err != nil {
+			return err
+		}
+	}
+
+	return s.writeLogsFile(s.format(record))
+}
+
+// Flush commits the current file to stable storage
+func (s *FileSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fileStream == nil {
+		return nil
+	}
+	return s.fileStream.Sync()
+}
+
+// Close closes the current file and waits for the background compression of rotated files
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	var err error
+	if s.fileStream != nil {
+		err = s.fileStream.Close()
+		s.fileStream = nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	s.compressWG.Wait()
+	return err
+}
+
+// SetFilename sets the file name, folder path, and maximum number of entries
+func (s *FileSink) SetFilename(filename, pathFolder string, maxEntries int) error {
+	if !isValidFilename(filename) || !isValidPathFolder(pathFolder) || maxEntries <= 0 {
+		return fmt.Errorf("invalid filename, path folder, or max_entries")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fileStream != nil {
+		_ = s.fileStream.Close()
+		s.fileStream = nil
+	}
+
+	s.filename = filename
+	s.maxEntries = maxEntries
+	s.maxEntriesCounter = maxEntries
+	s.pathFolder = pathFolder
+
+	s.logFileNumber = 0
+	s.logQueueFiles = nil
+
+	err := s.createFolder()
+	if err != nil {
+		return err
+	}
+	s.addCurrentFiles()
+	s.deleteAllFiles()
+	s.openFile()
+
+	return nil
+}
+
+// SetMaxEntries sets the maximum number of entries
+func (s *FileSink) SetMaxEntries(maxEntries int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxEntries-s.maxEntriesCounter < maxEntries {
+		if s.logFileNumber+1 == s.maxFiles {
+			s.logFileNumber = 0
+		} else {
+			s.logFileNumber++
+		}
+	}
+
+	s.maxEntries = maxEntries
+	s.maxEntriesCounter = maxEntries
+}
+
+// SetMaxBytes sets the maximum size of one file in bytes, 0 disables size-based rotation.
+// Combined with SetMaxEntries the file is rotated when either limit is reached,
+// SetMaxEntries(0) leaves the size as the only limit
+func (s *FileSink) SetMaxBytes(maxBytes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	s.maxBytes = maxBytes
+}
+
+// SetRotationPolicy sets time-based rotation, combined with the entry and size limits.
+// The current period is part of the file names: #N_<period>_<filename>
+func (s *FileSink) SetRotationPolicy(policy RotationPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotation = policy
+	now := s.now()
+	if period := policy.periodName(now); period != s.period {
+		return s.startPeriod(period, policy.periodStart(now))
+	}
+	s.periodStart = policy.periodStart(now)
+	return nil
+}
+
+// SetClock sets the clock used by time-based rotation and retention, nil restores the system clock.
+// Records are rotated by their own time, so the logger clock applies to them
+func (s *FileSink) SetClock(clock Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+}
+
+// now returns the time of the sink clock, the caller must hold s.mu
+func (s *FileSink) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// SetCompression sets the compression of rotated files, performed in the background
+func (s *FileSink) SetCompression(compression Compression) error {
+	if compression < NoCompression || compression > Gzip {
+		return fmt.Errorf("invalid compression")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.compression = compression
+	return nil
+}
+
+// SetMaxAge sets the maximum age of log files by modification time, 0 keeps files regardless of age.
+// Expired files are deleted immediately and then at every rotation
+func (s *FileSink) SetMaxAge(maxAge time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	s.maxAge = maxAge
+	s.deleteExpiredFiles()
+}
+
+// SetMaxFiles sets the maximum number of files
+func (s *FileSink) SetMaxFiles(maxFiles int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxFiles = maxFiles
+}
+
+func (s *FileSink) openFile() {
+	for {
+		if len(s.logQueueFiles) == 0 {
+			for s.maxFiles < len(s.logQueueFiles)+1 && s.maxFiles != 0 {
+				s.deleteFirstFile()
+			}
+		}
+
+		if s.maxEntries > s.getCountOfLines() || s.maxEntries == 0 {
+			break
+		}
+
+		s.logQueueFiles = append(s.logQueueFiles, s.segmentName(s.logFileNumber))
+
+		if s.logFileNumber+1 == s.maxFiles {
+			s.logFileNumber = 0
+		} else {
+			s.logFileNumber++
+		}
+	}
+
+	// Compressed and full files of a previous run keep their numbers, continue after them.
+	// A full file is only overwritten when it is the oldest one
+	var filePath string
+	var numLines int
+	for {
+		filePath = filepath.Join(s.pathFolder, s.segmentName(s.logFileNumber))
+		if checkFileExist(filePath + Gzip.compressedExtension()) {
+			s.logFileNumber++
+			continue
+		}
+
+		var err error
+		if numLines, err = countFileLines(filePath); err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		s.queueFile(filePath)
+		if s.maxEntries == 0 || numLines < s.maxEntries {
+			break
+		}
+		if s.logQueueFiles[0] == filePath {
+			s.deleteFirstFile()
+			s.queueFile(filePath)
+			numLines = 0
+			break
+		}
+		s.logFileNumber++
+	}
+
+	if numLines > 0 {
+		s.maxEntriesCounter = s.maxEntries - numLines
+	}
+
+	s.currentSize = 0
+
+	var err error
+	if s.fileStream, err = os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		fmt.Printf("Error opening file: %v\n", err)
+		return
+	}
+	s.currentPath = filePath
+
+	if info, err := s.fileStream.Stat(); err == nil {
+		s.currentSize = info.Size()
+	}
+}
+
+// countFileLines returns the number of complete lines of the file, 0 when it does not exist
+func countFileLines(filePath string) (int, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Count(data, []byte{'\n'}), nil
+}
+
+func checkFileExist(filename string) bool {
+	_, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false
+		} else {
+			fmt.Println(err)
+		}
+	}
+	return true
+}
+
+func (s *FileSink) deleteFirstFile() {
+	if len(s.logQueueFiles) > 0 {
+		filePath := s.logQueueFiles[0]
+		if err := os.Remove(filePath); err != nil {
+			fmt.Printf("Error deleting file: %v\n", err)
+		}
+		s.logQueueFiles = s.logQueueFiles[1:]
+	}
+}
+
+// deleteExpiredFiles deletes the files older than maxAge except the current one
+func (s *FileSink) deleteExpiredFiles() {
+	if s.maxAge <= 0 {
+		return
+	}
+
+	expiration := s.now().Add(-s.maxAge)
+	kept := s.logQueueFiles[:0]
+	for _, filePath := range s.logQueueFiles {
+		if filePath != s.currentPath {
+			info, err := os.Stat(filePath)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err == nil && info.ModTime().Before(expiration) {
+				if err := os.Remove(filePath); err != nil {
+					fmt.Printf("Error deleting file: %v\n", err)
+				}
+				continue
+			}
+		}
+		kept = append(kept, filePath)
+	}
+	s.logQueueFiles = kept
+}
+
+func (s *FileSink) deleteAllFiles() {
+	for len(s.logQueueFiles) > 0 {
+		s.deleteFirstFile()
+	}
+}
+
+func (s *FileSink) writeLogsToFile(formattedMessage string) error {
+	if s.closed {
+		return fmt.Errorf("error writing to file: %v", os.ErrClosed)
+	}
+	if s.fileStream == nil {
+		s.openFile()
+	}
+
+	n, err := io.WriteString(s.fileStream, formattedMessage+"\n")
+	s.currentSize += int64(n)
+	if err != nil {
+		return fmt.Errorf("error writing to file: %v", err)
+	}
+	return nil
+}
+
+// exceedsMaxBytes reports whether writing size more bytes would overflow a non-empty current file
+func (s *FileSink) exceedsMaxBytes(size int) bool {
+	return s.maxBytes > 0 && s.currentSize > 0 && s.currentSize+int64(size) > s.maxBytes
+}
+
+func (s *FileSink) writeLogsFile(formattedMessage string) error {
+	size := len(formattedMessage) + 1
+	if (s.maxEntriesCounter > 0 || s.maxEntries == 0) && !s.exceedsMaxBytes(size) {
+		s.maxEntriesCounter--
+		return s.writeLogsToFile(formattedMessage)
+	} else {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		// The next file may be left by a previous run, skip files the line does not fit in
+		for s.exceedsMaxBytes(size) {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+		return s.writeLogsToFile(formattedMessage)
+	}
+}
+
+// rotate closes the current file and opens the next one
+func (s *FileSink) rotate() error {
+	s.maxEntriesCounter = s.maxEntries
+	if s.fileStream != nil {
+		err := s.fileStream.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(s.logQueueFiles) >= s.maxFiles {
+		s.deleteFirstFile()
+	}
+	s.deleteExpiredFiles()
+
+	s.logFileNumber++
+
+	closedPath := s.currentPath
+	s.openFile()
+
+	if s.compression != NoCompression && closedPath != "" && closedPath != s.currentPath {
+		s.compressWG.Add(1)
+		go s.compressFile(closedPath, s.compression)
+	}
+	return nil
+}
+
+// startPeriod moves to a file of the period. An empty current file is reopened under the name
+// of the period instead of being rotated, so no empty files are left behind
+func (s *FileSink) startPeriod(period string, start time.Time) error {
+	s.period = period
+	s.periodStart = start
+
+	if s.fileStream == nil || s.currentSize > 0 {
+		return s.rotate()
+	}
+
+	if err := s.fileStream.Close(); err != nil {
+		return err
+	}
+	s.fileStream = nil
+
+	if err := os.Remove(s.currentPath); err != nil {
+		fmt.Printf("Error deleting file: %v\n", err)
+	}
+	for i, file := range s.logQueueFiles {
+		if file == s.currentPath {
+			s.logQueueFiles = append(s.logQueueFiles[:i], s.logQueueFiles[i+1:]...)
+			break
+		}
+	}
+
+	s.openFile()
+	return nil
+}
+
+// segmentName returns the file name with the given number and the current rotation period
+func (s *FileSink) segmentName(number int) string {
+	if s.period == "" {
+		return fmt.Sprintf("#%d%s", number, s.filename)
+	}
+	return fmt.Sprintf("#%d_%s_%s", number, s.period, s.filename)
+}
+
+func (s *FileSink) addCurrentFiles() {
+	var folder string
+	if s.pathFolder == "" {
+		folder = "."
+	} else {
+		folder = s.pathFolder
+	}
+
+	files, err := filepath.Glob(filepath.Join(folder, fmt.Sprintf("*%s", s.filename)))
+	if err != nil {
+		fmt.Printf("Error reading files in directory: %v\n", err)
+		return
+	}
+
+	compressedFiles, err := filepath.Glob(filepath.Join(folder, fmt.Sprintf("*%s%s", s.filename, Gzip.compressedExtension())))
+	if err != nil {
+		fmt.Printf("Error reading files in directory: %v\n", err)
+		return
+	}
+
+	files = append(files, compressedFiles...)
+	sort.Strings(files)
+
+	for _, file := range files {
+		s.queueFile(file)
+	}
+}
+
+// queueFile adds the file to the retention queue unless it is already there
+func (s *FileSink) queueFile(filePath string) {
+	for _, file := range s.logQueueFiles {
+		if file == filePath {
+			return
+		}
+	}
+	s.logQueueFiles = append(s.logQueueFiles, filePath)
+}
+
+func (s *FileSink) createFolder() error {
+	if s.pathFolder != "" {
+		if _, err := os.Stat(s.pathFolder); os.IsNotExist(err) {
+			if err := os.Mkdir(s.pathFolder, 0755); err != nil {
+				return fmt.Errorf("failed to create folder: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *FileSink) getCountOfLines() int {
+
+	filePath := s.segmentName(s.logFileNumber)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	var lineCount int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineCount++
+	}
+
+	return lineCount
+}
+
+func isValidFilename(filename string) bool {
+	pattern := regexp.MustCompile(`[a-zA-Z0-9_]+\.(txt|log)`)
+	return pattern.MatchString(filename)
+}
+
+func isValidPathFolder(pathFolder string) bool {
+	if pathFolder == "" {
+		return true
+	}
+	pattern := regexp.MustCompile(`.+[a-zA-Z0-9_]`)
+	return pattern.MatchString(pathFolder)
+}
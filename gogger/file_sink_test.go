@@ -0,0 +1,295 @@
+package gogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestFileSink(t *testing.T, dir string, maxEntries, maxFiles int) *FileSink {
+	sink, err := NewFileSink("test.log", dir, maxEntries, maxFiles)
+	if err != nil {
+		t.Fatalf("NewFileSink returned unexpected error: %v", err)
+	}
+	formatter, err := NewTextFormatter("%message%")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	sink.SetFormatter(formatter)
+	t.Cleanup(func() { _ = sink.Close() })
+	return sink
+}
+
+func TestFileSinkSizeRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newTestFileSink(t, tempDir, 100, 10)
+	sink.SetMaxBytes(30)
+
+	message := strings.Repeat("a", 9) // 10 bytes with the newline
+	for i := 0; i < 7; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: message}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	for i, want := range []int64{30, 30, 10} {
+		info, err := os.Stat(filepath.Join(tempDir, "#"+string(rune('0'+i))+"test.log"))
+		if err != nil {
+			t.Fatalf("Failed to stat log file %d: %v", i, err)
+		}
+		if info.Size() != want {
+			t.Errorf("Expected file %d to have %d bytes, got %d", i, want, info.Size())
+		}
+	}
+}
+
+func TestFileSinkSizeRotationLargeLine(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newTestFileSink(t, tempDir, 100, 10)
+	sink.SetMaxBytes(10)
+
+	large := strings.Repeat("b", 100)
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: large}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(tempDir, "#*test.log"))
+	if err != nil {
+		t.Fatalf("Failed to list log files: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("Expected one file per oversized line, got %d files", len(files))
+	}
+}
+
+func TestFileSinkSizeTrackedOnStartup(t *testing.T) {
+	tempDir := t.TempDir()
+	existing := strings.Repeat("c", 19) + "\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "#0test.log"), []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	sink := newTestFileSink(t, tempDir, 100, 10)
+	if sink.currentSize != int64(len(existing)) {
+		t.Errorf("Expected current size %d, got %d", len(existing), sink.currentSize)
+	}
+
+	sink.SetMaxBytes(24)
+	if err := sink.Write(&Record{Level: INFO, Message: "next"}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "#1test.log")); err != nil {
+		t.Errorf("Expected rotation to #1test.log: %v", err)
+	}
+}
+
+func TestFileSinkSizeRotationSkipsFullFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	existing := strings.Repeat("d", 39) + "\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "#1test.log"), []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	sink := newTestFileSink(t, tempDir, 100, 10)
+	sink.SetMaxBytes(60)
+
+	message := strings.Repeat("e", 35) // 36 bytes with the newline
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: message}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	for i, want := range []int64{36, int64(len(existing)), 36} {
+		info, err := os.Stat(filepath.Join(tempDir, "#"+string(rune('0'+i))+"test.log"))
+		if err != nil {
+			t.Fatalf("Failed to stat log file %d: %v", i, err)
+		}
+		if info.Size() != want {
+			t.Errorf("Expected file %d to have %d bytes, got %d", i, want, info.Size())
+		}
+	}
+}
+
+func TestFileSinkSizeOnlyRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newTestFileSink(t, tempDir, 1, 10)
+	sink.SetMaxEntries(0)
+	sink.SetMaxBytes(100)
+
+	for i := 0; i < 10; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: "entry"}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(tempDir, "#*test.log"))
+	if err != nil {
+		t.Fatalf("Failed to list log files: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected a single file without entry limit, got %d", len(files))
+	}
+}
+
+// logFileNames returns the names of the files in the folder
+func logFileNames(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read log folder: %v", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func newDailyTestFileSink(t *testing.T, dir string, now time.Time) *FileSink {
+	sink := newTestFileSink(t, dir, 100, 10)
+	sink.SetClock(NewFakeClock(now))
+
+	policy, err := RotateDaily(0, 0)
+	if err != nil {
+		t.Fatalf("RotateDaily returned unexpected error: %v", err)
+	}
+	if err := sink.SetRotationPolicy(policy); err != nil {
+		t.Fatalf("SetRotationPolicy returned unexpected error: %v", err)
+	}
+	return sink
+}
+
+func TestFileSinkTimeRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newDailyTestFileSink(t, tempDir, time.Date(2020, 9, 29, 22, 0, 0, 0, time.Local))
+
+	days := []time.Time{
+		time.Date(2020, 9, 29, 23, 0, 0, 0, time.Local),
+		time.Date(2020, 9, 29, 23, 30, 0, 0, time.Local),
+		time.Date(2020, 9, 30, 0, 1, 0, 0, time.Local),
+	}
+	for _, day := range days {
+		if err := sink.Write(&Record{Time: day, Level: INFO, Message: day.Format(time.RFC3339)}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	names := logFileNames(t, tempDir)
+	if len(names) != 2 {
+		t.Fatalf("Expected one file per period, got %v", names)
+	}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read log file %s: %v", name, err)
+		}
+		period := strings.Split(name, "_")[1]
+		if len(content) == 0 || strings.Count(string(content), period) != strings.Count(string(content), "\n") {
+			t.Errorf("Log file %s does not contain only records of its period: %q", name, content)
+		}
+	}
+}
+
+func TestFileSinkTimeRotationOutOfOrder(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := newDailyTestFileSink(t, tempDir, time.Date(2020, 9, 29, 23, 0, 0, 0, time.Local))
+
+	beforeMidnight := time.Date(2020, 9, 29, 23, 59, 59, 0, time.Local)
+	midnight := time.Date(2020, 9, 30, 0, 0, 0, 0, time.Local)
+	for _, recordTime := range []time.Time{beforeMidnight, midnight, beforeMidnight, midnight} {
+		if err := sink.Write(&Record{Time: recordTime, Level: INFO, Message: "entry"}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	names := logFileNames(t, tempDir)
+	if len(names) != 2 {
+		t.Fatalf("Expected a file for each of the two periods, got %v", names)
+	}
+
+	content, err := os.ReadFile(sink.currentPath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(sink.currentPath, "2020-09-30") || strings.Count(string(content), "\n") != 3 {
+		t.Errorf("Expected the late record in the file of the later period, got %s: %q", sink.currentPath, content)
+	}
+}
+
+func TestFileSinkMaxAge(t *testing.T) {
+	tempDir := t.TempDir()
+	old := time.Now().Add(-31 * 24 * time.Hour)
+	recent := time.Now().Add(-24 * time.Hour)
+
+	for name, modTime := range map[string]time.Time{"#1test.log": old, "#2test.log.gz": old, "#3test.log": recent} {
+		createTestFile(t, tempDir, name)
+		if err := os.Chtimes(filepath.Join(tempDir, name), modTime, modTime); err != nil {
+			t.Fatalf("Failed to set modification time: %v", err)
+		}
+	}
+
+	sink := newTestFileSink(t, tempDir, 1, 10)
+	sink.SetMaxAge(30 * 24 * time.Hour)
+
+	for name, wantExist := range map[string]bool{"#1test.log": false, "#2test.log.gz": false, "#3test.log": true} {
+		_, err := os.Stat(filepath.Join(tempDir, name))
+		if exists := err == nil; exists != wantExist {
+			t.Errorf("File %s exists = %v, want %v", name, exists, wantExist)
+		}
+	}
+
+	if err := os.Chtimes(filepath.Join(tempDir, "#3test.log"), old, old); err != nil {
+		t.Fatalf("Failed to set modification time: %v", err)
+	}
+
+	// The second write rotates and applies retention again
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: "entry"}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "#3test.log")); !os.IsNotExist(err) {
+		t.Error("Expected expired file to be deleted at rotation")
+	}
+	if _, err := os.Stat(sink.currentPath); err != nil {
+		t.Errorf("Current file must not be deleted: %v", err)
+	}
+}
+
+func TestFileSinkRestartWithFullOldestFile(t *testing.T) {
+	tempDir := t.TempDir()
+	full := strings.Repeat("entry\n", 5)
+	if err := os.WriteFile(filepath.Join(tempDir, "#0test.log"), []byte(full), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	sink := newTestFileSink(t, tempDir, 5, 3)
+	for i := 0; i < 60; i++ {
+		if err := sink.Write(&Record{Level: INFO, Message: "entry"}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+
+	if names := logFileNames(t, tempDir); len(names) > 3 {
+		t.Errorf("Expected at most 3 files, got %v", names)
+	}
+}
+
+func TestFileSinkCloseTwice(t *testing.T) {
+	sink := newTestFileSink(t, t.TempDir(), 100, 10)
+	if err := sink.Write(&Record{Level: INFO, Message: "message"}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := sink.Close(); err != nil {
+			t.Errorf("Close %d returned unexpected error: %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package gogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Record is a single log entry handed to formatters
+type Record struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	Fields  []Field
+	Logger  string
+	// Caller is only filled in when a sink renders it
+	Caller Caller
+
+	timestampLayout string
+	location        *time.Location
+}
+
+// Formatter converts a record into a single line of output without the trailing newline
+type Formatter interface {
+	Format(record *Record) string
+}
+
+// TextFormatter renders records with a format of placeholders compiled once, see template for the syntax
+type TextFormatter struct {
+	template *template
+}
+
+// NewTextFormatter creates a TextFormatter for the given format
+func NewTextFormatter(format string) (*TextFormatter, error) {
+	compiled, err := parseTemplate(format)
+	if err != nil {
+		return nil, err
+	}
+
+	if !compiled.has(nodeTimestamp, nodeLevel, nodeMessage, nodeFields, nodeLogger) {
+		return nil, fmt.Errorf("invalid log format. The format must contain at least one of the following elements: %%timestamp%%, %%level%%, %%message%%, %%fields%%, %%logger%%")
+	}
+
+	return &TextFormatter{template: compiled}, nil
+}
+
+// newDefaultTextFormatter creates a TextFormatter for defaultLogFormat
+func newDefaultTextFormatter() *TextFormatter {
+	formatter, err := NewTextFormatter(defaultLogFormat)
+	if err != nil {
+		panic(err)
+	}
+	return formatter
+}
+
+// colorFormatter is implemented by formatters able to render the level name in color
+type colorFormatter interface {
+	formatColored(record *Record) string
+}
+
+// Format renders the record using the format string
+func (f *TextFormatter) Format(record *Record) string {
+	return f.template.render(record, false)
+}
+
+// formatColored renders the record with the level name wrapped in its console color
+func (f *TextFormatter) formatColored(record *Record) string {
+	return f.template.render(record, true)
+}
+
+// NeedsCaller reports whether the format contains a caller placeholder
+func (f *TextFormatter) NeedsCaller() bool {
+	return f.template.has(nodeFile, nodeLine, nodeFunc)
+}
+
+// JSONFormatter renders records as one JSON object per line
+type JSONFormatter struct{}
+
+// NewJSONFormatter creates a JSONFormatter
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+// Format renders the record as a JSON object with timestamp, level, message, logger name and fields
+func (f *JSONFormatter) Format(record *Record) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "timestamp", record.Timestamp())
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "level", getLogLevelString(record.Level))
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "message", record.Message)
+	if record.Logger != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "logger", record.Logger)
+	}
+
+	for _, field := range record.Fields {
+		key := field.Key
+		if key == "timestamp" || key == "level" || key == "message" || key == "logger" {
+			key = "fields." + key
+		}
+		buf.WriteByte(',')
+		writeJSONPair(&buf, key, field.Value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.String()
+}
+
+// LogfmtFormatter renders records as logfmt lines: time, level, msg, caller, logger and fields as key=value pairs
+type LogfmtFormatter struct{}
+
+// NewLogfmtFormatter creates a LogfmtFormatter
+func NewLogfmtFormatter() *LogfmtFormatter {
+	return &LogfmtFormatter{}
+}
+
+// Format renders the record as space separated key=value pairs, quoting values when needed
+func (f *LogfmtFormatter) Format(record *Record) string {
+	var sb strings.Builder
+
+	writeLogfmtPair(&sb, "time", record.Timestamp())
+	writeLogfmtPair(&sb, "level", getLogLevelString(record.Level))
+	writeLogfmtPair(&sb, "msg", record.Message)
+	if record.Caller.File != "" {
+		writeLogfmtPair(&sb, "caller", record.Caller.ShortFile()+":"+strconv.Itoa(record.Caller.Line))
+	}
+	if record.Logger != "" {
+		writeLogfmtPair(&sb, "logger", record.Logger)
+	}
+
+	for _, field := range record.Fields {
+		key := logfmtKey(field.Key)
+		if key == "time" || key == "level" || key == "msg" || key == "caller" || key == "logger" {
+			key = "fields." + key
+		}
+		writeLogfmtPair(&sb, key, field.Value)
+	}
+
+	return sb.String()
+}
+
+// NeedsCaller reports that the caller is always rendered
+func (f *LogfmtFormatter) NeedsCaller() bool {
+	return true
+}
+
+func writeLogfmtPair(sb *strings.Builder, key string, value any) {
+	if sb.Len() > 0 {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(key)
+	sb.WriteByte('=')
+	sb.WriteString(formatFieldValue(value))
+}
+
+// logfmtKey replaces the characters that would break the key=value pair with underscores
+func logfmtKey(key string) string {
+	if key == "" {
+		return badKey
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func writeJSONPair(buf *bytes.Buffer, key string, value any) {
+	writeJSONValue(buf, key)
+	buf.WriteByte(':')
+	writeJSONValue(buf, value)
+}
+
+func writeJSONValue(buf *bytes.Buffer, value any) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		encoded.Reset()
+		_ = encoder.Encode(fmt.Sprint(value))
+	}
+
+	// Encode always terminates the value with a newline
+	buf.Write(bytes.TrimRight(encoded.Bytes(), "\n"))
+}
@@ -0,0 +1,131 @@
+package gogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextFormatter(t *testing.T) {
+	formatter, err := NewTextFormatter("[%level%] %message% %fields%")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+
+	record := &Record{Time: time.Now(), Level: WARNING, Message: "disk low", Fields: []Field{F("free", "1GB")}}
+	if got := formatter.Format(record); got != "[WARNING] disk low free=1GB" {
+		t.Errorf("Format() = %q", got)
+	}
+
+	if _, err := NewTextFormatter("Invalid format"); err == nil {
+		t.Error("NewTextFormatter should return an error for invalid format")
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	timestamp := time.Date(2020, 9, 30, 21, 59, 5, 0, time.Local)
+	record := &Record{
+		Time:    timestamp,
+		Level:   ERROR,
+		Message: "line one\nline \"two\" [x]",
+		Fields:  []Field{F("user", 42), F("err", errors.New("boom")), F("message", "duplicate")},
+	}
+
+	line := NewJSONFormatter().Format(record)
+
+	if strings.Contains(line, "\n") {
+		t.Fatalf("JSON line contains a raw newline: %q", line)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON line %q: %v", line, err)
+	}
+
+	expected := map[string]any{
+		"timestamp":      "30-09-2020 21:59:05",
+		"level":          "ERROR",
+		"message":        record.Message,
+		"user":           float64(42),
+		"err":            "boom",
+		"fields.message": "duplicate",
+	}
+	for key, want := range expected {
+		if decoded[key] != want {
+			t.Errorf("Expected %s = %v, got %v", key, want, decoded[key])
+		}
+	}
+
+	if !strings.HasPrefix(line, `{"timestamp":`) {
+		t.Errorf("Expected timestamp to be the first key: %s", line)
+	}
+}
+
+func TestSetFormatterFile(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	defer Logger.Close()
+
+	Logger.SetFormatterFile(NewJSONFormatter())
+	Logger.InfoW("JSON message", "key", "value")
+
+	if _, ok := Logger.consoleSink.formatter.(*TextFormatter); !ok {
+		t.Errorf("Expected console formatter to stay a TextFormatter, got %T", Logger.consoleSink.formatter)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(content))), &decoded); err != nil {
+		t.Fatalf("Log file does not contain a JSON line: %v", err)
+	}
+	if decoded["message"] != "JSON message" || decoded["key"] != "value" {
+		t.Errorf("Unexpected JSON record: %v", decoded)
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	record := &Record{
+		Time:            time.Date(2020, 9, 30, 21, 59, 5, 0, time.UTC),
+		Level:           ERROR,
+		Message:         "request \"failed\"\nretrying",
+		Logger:          "http",
+		Caller:          Caller{File: "/src/app/server.go", Line: 42, Function: "main.serve"},
+		Fields:          []Field{F("status", 500), F("path", "/a b"), F("empty", ""), F("level", "dup"), F("bad key", "x=y"), F("err", errors.New("boom"))},
+		timestampLayout: TimestampRFC3339,
+	}
+
+	line := NewLogfmtFormatter().Format(record)
+
+	expected := `time=2020-09-30T21:59:05Z level=ERROR msg="request \"failed\"\nretrying" caller=server.go:42 logger=http ` +
+		`status=500 path="/a b" empty="" fields.level=dup bad_key="x=y" err=boom`
+	if line != expected {
+		t.Errorf("Expected %q, got %q", expected, line)
+	}
+}
+
+func TestLogfmtFormatterCaller(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	sink.SetFormatter(NewLogfmtFormatter())
+
+	logger := newTestLogger(t, sink)
+	defer logger.Close()
+
+	line := nextLine()
+	logger.InfoW("started", "port", 8080)
+
+	expected := fmt.Sprintf("level=INFO msg=started caller=formatter_test.go:%d port=8080\n", line)
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected output to end with %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,233 @@
+package gogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+const (
+	gelfVersion = "1.1"
+	// gelfMaxChunks is the maximum number of chunks of a UDP message allowed by GELF
+	gelfMaxChunks = 128
+	// gelfChunkHeaderSize is the size of the magic bytes, message ID, sequence number and count of a chunk
+	gelfChunkHeaderSize = 12
+	// defaultGELFChunkSize fits a chunk in the MTU of common networks
+	defaultGELFChunkSize = 1420
+	minGELFChunkSize     = 512
+)
+
+var (
+	gelfChunkMagic     = []byte{0x1e, 0x0f}
+	gelfFieldKeyFilter = regexp.MustCompile(`[^\w.\-]`)
+)
+
+// GELFSink sends records to Graylog in the Graylog Extended Log Format over "udp" or "tcp".
+// UDP messages are compressed with gzip and split into chunks when they exceed the chunk size,
+// TCP messages are uncompressed and terminated by a null byte. A failed TCP connection is dialed
+// again on the next write. It is safe for concurrent use
+type GELFSink struct {
+	sinkBase
+	conn        netConn
+	host        string
+	compression Compression
+	chunkSize   int
+}
+
+// NewGELFSink creates a sink sending records to the Graylog input at the address,
+// such as NewGELFSink("udp", "graylog:12201")
+func NewGELFSink(network, address string) (*GELFSink, error) {
+	compression := NoCompression
+	switch network {
+	case "udp", "udp4", "udp6":
+		compression = Gzip
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("invalid GELF network %q", network)
+	}
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+
+	s := &GELFSink{
+		sinkBase:    sinkBase{level: DEBUG},
+		conn:        netConn{network: network, address: address, server: "GELF input"},
+		host:        host,
+		compression: compression,
+		chunkSize:   defaultGELFChunkSize,
+	}
+
+	if err := s.conn.dial(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Write encodes the record as a GELF message and sends it in one or more packets
+func (s *GELFSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	packets, err := s.packets(s.encode(record))
+	if err != nil {
+		return err
+	}
+	return s.conn.write(packets)
+}
+
+// Flush does nothing, GELF messages are sent as they are written
+func (s *GELFSink) Flush() error {
+	return nil
+}
+
+// Close closes the connection to the Graylog input
+func (s *GELFSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.close()
+}
+
+// SetHost sets the host sent with the records, the name of the machine by default
+func (s *GELFSink) SetHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("invalid GELF host. The host must not be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.host = host
+	return nil
+}
+
+// SetCompression sets the compression of UDP messages, Gzip by default. TCP messages are never compressed
+func (s *GELFSink) SetCompression(compression Compression) error {
+	if compression < NoCompression || compression > Gzip {
+		return fmt.Errorf("invalid compression")
+	}
+	if compression != NoCompression && !s.isUDP() {
+		return fmt.Errorf("GELF over TCP does not support compression")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compression = compression
+	return nil
+}
+
+// SetChunkSize sets the maximum size of a UDP datagram, 1420 bytes by default
+func (s *GELFSink) SetChunkSize(chunkSize int) error {
+	if chunkSize < minGELFChunkSize {
+		return fmt.Errorf("invalid chunk size. The chunk size must be at least %d bytes", minGELFChunkSize)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunkSize = chunkSize
+	return nil
+}
+
+func (s *GELFSink) isUDP() bool {
+	return s.conn.isDatagram()
+}
+
+// encode renders the record as a GELF JSON object, the caller must hold s.mu
+func (s *GELFSink) encode(record *Record) []byte {
+	message := record.Message
+	if s.formatter != nil {
+		message = s.formatter.Format(record)
+	}
+	if message == "" {
+		message = "-"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONPair(&buf, "version", gelfVersion)
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "host", s.host)
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "short_message", message)
+	buf.WriteByte(',')
+	writeJSONValue(&buf, "timestamp")
+	buf.WriteByte(':')
+	buf.WriteString(strconv.FormatFloat(float64(record.Time.UnixMilli())/1000, 'f', 3, 64))
+	buf.WriteByte(',')
+	writeJSONPair(&buf, "level", syslogSeverity(record.Level))
+	if record.Logger != "" {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, "_logger", record.Logger)
+	}
+
+	for _, field := range record.Fields {
+		buf.WriteByte(',')
+		writeJSONPair(&buf, gelfFieldKey(field.Key), field.Value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes()
+}
+
+// packets compresses and chunks the message for UDP or terminates it with a null byte for TCP.
+// The caller must hold s.mu
+func (s *GELFSink) packets(message []byte) ([][]byte, error) {
+	if !s.isUDP() {
+		return [][]byte{append(message, 0)}, nil
+	}
+
+	if s.compression == Gzip {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(message); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		message = buf.Bytes()
+	}
+
+	if len(message) <= s.chunkSize {
+		return [][]byte{message}, nil
+	}
+
+	dataSize := s.chunkSize - gelfChunkHeaderSize
+	count := (len(message) + dataSize - 1) / dataSize
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("GELF message of %d bytes exceeds %d chunks", len(message), gelfMaxChunks)
+	}
+
+	messageID := make([]byte, 8)
+	if _, err := rand.Read(messageID); err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		data := message[i*dataSize : min(len(message), (i+1)*dataSize)]
+
+		chunk := make([]byte, 0, gelfChunkHeaderSize+len(data))
+		chunk = append(chunk, gelfChunkMagic...)
+		chunk = append(chunk, messageID...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks = append(chunks, append(chunk, data...))
+	}
+	return chunks, nil
+}
+
+// gelfFieldKey prefixes the key with an underscore as required for additional fields,
+// replacing the characters GELF does not allow. The reserved "_id" becomes "__id"
+func gelfFieldKey(key string) string {
+	key = "_" + gelfFieldKeyFilter.ReplaceAllString(key, "_")
+	if key == "_id" {
+		return "__id"
+	}
+	return key
+}
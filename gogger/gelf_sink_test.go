@@ -0,0 +1,241 @@
+package gogger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestGELFSink(t *testing.T, network, address string) *GELFSink {
+	sink, err := NewGELFSink(network, address)
+	if err != nil {
+		t.Fatalf("NewGELFSink returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = sink.Close() })
+
+	if err := sink.SetHost("host"); err != nil {
+		t.Fatalf("SetHost returned unexpected error: %v", err)
+	}
+	return sink
+}
+
+// readGELFDatagram reads a UDP message, reassembling its chunks, and decompresses it
+func readGELFDatagram(t *testing.T, conn net.PacketConn) map[string]any {
+	buf := make([]byte, 65536)
+	chunks := map[byte][]byte{}
+	var message []byte
+
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Failed to read GELF message: %v", err)
+		}
+		packet := append([]byte(nil), buf[:n]...)
+
+		if !bytes.HasPrefix(packet, gelfChunkMagic) {
+			message = packet
+			break
+		}
+		if len(packet) > 1000 {
+			t.Errorf("Chunk of %d bytes exceeds the chunk size", len(packet))
+		}
+		chunks[packet[10]] = packet[gelfChunkHeaderSize:]
+		if count := int(packet[11]); len(chunks) == count {
+			for i := 0; i < count; i++ {
+				message = append(message, chunks[byte(i)]...)
+			}
+			break
+		}
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("GELF message is not gzip compressed: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress GELF message: %v", err)
+	}
+
+	return decodeGELF(t, decompressed)
+}
+
+func decodeGELF(t *testing.T, message []byte) map[string]any {
+	var decoded map[string]any
+	if err := json.Unmarshal(message, &decoded); err != nil {
+		t.Fatalf("GELF message is not valid JSON %q: %v", message, err)
+	}
+	return decoded
+}
+
+func TestGELFSinkUDP(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink := newTestGELFSink(t, "udp", listener.LocalAddr().String())
+
+	record := &Record{
+		Time:    time.Date(2020, 9, 30, 21, 59, 5, 123000000, time.UTC),
+		Level:   ERROR,
+		Message: "request failed",
+		Logger:  "http",
+		Fields:  []Field{F("status", 500), F("user id", "u1"), F("id", 7)},
+	}
+	if err := sink.Write(record); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	message := readGELFDatagram(t, listener)
+	expected := map[string]any{
+		"version":       "1.1",
+		"host":          "host",
+		"short_message": "request failed",
+		"timestamp":     1601503145.123,
+		"level":         float64(3),
+		"_logger":       "http",
+		"_status":       float64(500),
+		"_user_id":      "u1",
+		"__id":          float64(7),
+	}
+	for key, value := range expected {
+		if message[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, message[key])
+		}
+	}
+}
+
+func TestGELFSinkUDPChunking(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink := newTestGELFSink(t, "udp", listener.LocalAddr().String())
+	if err := sink.SetChunkSize(1000); err != nil {
+		t.Fatalf("SetChunkSize returned unexpected error: %v", err)
+	}
+
+	// Random-looking text does not compress below the chunk size
+	var sb strings.Builder
+	for i := 0; sb.Len() < 20000; i++ {
+		sb.WriteString(time.Duration(i * 7919).String())
+	}
+	longMessage := sb.String()
+
+	if err := sink.Write(&Record{Time: time.Now(), Level: INFO, Message: longMessage}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	message := readGELFDatagram(t, listener)
+	if message["short_message"] != longMessage {
+		t.Errorf("Reassembled message does not match the original")
+	}
+}
+
+func TestGELFSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	messages := make(chan []byte, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			message, err := reader.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			messages <- message[:len(message)-1]
+		}
+	}()
+
+	sink := newTestGELFSink(t, "tcp", listener.Addr().String())
+	if err := sink.SetCompression(Gzip); err == nil {
+		t.Error("SetCompression should return an error for TCP")
+	}
+
+	logger := newTestLogger(t, sink)
+	defer logger.Close()
+	logger.WarningW("disk low", "free", "1GB")
+	logger.Info("second")
+
+	for _, expected := range []string{"disk low", "second"} {
+		select {
+		case message := <-messages:
+			decoded := decodeGELF(t, message)
+			if decoded["short_message"] != expected {
+				t.Errorf("Expected short_message %q, got %v", expected, decoded["short_message"])
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for GELF message")
+		}
+	}
+}
+
+func TestGELFFieldKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"user", "_user"},
+		{"http.status", "_http.status"},
+		{"user id", "_user_id"},
+		{"id", "__id"},
+		{"a/b", "_a_b"},
+	}
+
+	for _, tt := range tests {
+		if got := gelfFieldKey(tt.key); got != tt.expected {
+			t.Errorf("gelfFieldKey(%q) = %q, expected %q", tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestGELFSinkValidation(t *testing.T) {
+	if _, err := NewGELFSink("unix", "/tmp/graylog.sock"); err == nil {
+		t.Error("NewGELFSink should return an error for an unsupported network")
+	}
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	sink := newTestGELFSink(t, "udp", listener.LocalAddr().String())
+
+	if err := sink.SetChunkSize(100); err == nil {
+		t.Error("SetChunkSize should return an error for a chunk size below 512")
+	}
+	if err := sink.SetHost(""); err == nil {
+		t.Error("SetHost should return an error for an empty host")
+	}
+
+	// A message needing more than 128 chunks is rejected
+	if err := sink.SetCompression(NoCompression); err != nil {
+		t.Fatalf("SetCompression returned unexpected error: %v", err)
+	}
+	if err := sink.SetChunkSize(512); err != nil {
+		t.Fatalf("SetChunkSize returned unexpected error: %v", err)
+	}
+	if err := sink.Write(&Record{Level: INFO, Message: strings.Repeat("x", 128*512)}); err == nil {
+		t.Error("Write should return an error for a message exceeding 128 chunks")
+	}
+}
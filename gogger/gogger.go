@@ -1,44 +1,47 @@
 package gogger
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
-	"io"
 	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var Logger *Gogger
 
-// LogLevel enumeration type for logging levels
-type LogLevel int
+const defaultLogFormat = "[%timestamp%] [%level%] %message%"
 
-const (
-	DEBUG LogLevel = iota
-	INFO
-	WARNING
-	ERROR
-)
+// osExit is replaced in tests of FATAL records
+var osExit = os.Exit
 
-// Gogger structure for logging
+// Gogger structure for logging. All methods are safe for concurrent use
 type Gogger struct {
-	filename          string
-	fileStream        *os.File
-	logLevelConsole   LogLevel
-	logLevelFile      LogLevel
-	logFormat         string
-	pathFolder        string
-	console           bool
-	file              bool
-	logQueueFiles     []string
-	maxEntries        int
-	maxEntriesCounter int
-	maxFiles          int
-	logFileNumber     int
+	*loggerCore
+	name       string
+	fields     []Field
+	callerSkip int
+}
+
+// loggerCore holds the outputs and settings shared by a Gogger and its children
+type loggerCore struct {
+	mu          sync.RWMutex
+	consoleSink *WriterSink
+	fileSink    *FileSink
+	sinks       []Sink
+	console     bool
+	file        bool
+
+	async          *asyncQueue
+	asyncDropLevel atomic.Int64
+	dropped        atomic.Uint64
+
+	named       map[string]*Gogger
+	namedLevels map[string]LogLevel
+
+	timestampLayout string
+	location        *time.Location
+	clock           Clock
 }
 
 // InitGogger initializes var Logger *Gogger
@@ -56,401 +59,477 @@ func InitGogger(filename, pathFolder string, maxEntries, maxFiles int) {
 		panic("invalid max_entries")
 	}
 
-	l := &Gogger{
-		filename:          filename,
-		pathFolder:        pathFolder,
-		maxEntries:        maxEntries,
-		maxEntriesCounter: maxEntries,
-		maxFiles:          maxFiles,
-		logLevelFile:      INFO,
-		logFormat:         "[%timestamp%] [%level%] %message%",
-		console:           true,
-		file:              true,
-	}
-
-	if err := l.createFolder(); err != nil {
+	fileSink, err := NewFileSink(filename, pathFolder, maxEntries, maxFiles)
+	if err != nil {
 		panic(err)
 	}
 
-	l.addCurrentFiles()
-	l.openFile()
-
-	Logger = l
+	Logger = newGogger(fileSink)
 }
 
 // NewGogger creates a new instance of Gogger
 func NewGogger(filename, pathFolder string, maxEntries, maxFiles int) (*Gogger, error) {
 
-	if !isValidFilename(filename) || !isValidPathFolder(pathFolder) || maxEntries <= 0 {
-		return nil, fmt.Errorf("invalid filename, path folder, or max_entries")
+	fileSink, err := NewFileSink(filename, pathFolder, maxEntries, maxFiles)
+	if err != nil {
+		return nil, err
 	}
 
-	l := &Gogger{
-		filename:          filename,
-		pathFolder:        pathFolder,
-		maxEntries:        maxEntries,
-		maxEntriesCounter: maxEntries,
-		maxFiles:          maxFiles,
-		logLevelFile:      INFO,
-		logFormat:         "[%timestamp%] [%level%] %message%",
-		console:           true,
-		file:              true,
-	}
+	return newGogger(fileSink), nil
+}
 
-	if err := l.createFolder(); err != nil {
-		return nil, err
+func newGogger(fileSink *FileSink) *Gogger {
+	core := &loggerCore{
+		consoleSink: NewConsoleSink(),
+		fileSink:    fileSink,
+		console:     true,
+		file:        true,
+		clock:       systemClock{},
 	}
+	core.asyncDropLevel.Store(int64(INFO))
 
-	l.addCurrentFiles()
-	l.openFile()
-
-	return l, nil
+	return &Gogger{loggerCore: core}
 }
 
-// Close closes the file stream when Gogger is destroyed
-func (l *Gogger) Close() {
-	if l.fileStream != nil {
-		_ = l.fileStream.Close()
+// With returns a child logger that adds the key/value pairs to every record.
+// The child shares outputs, rotation state and settings with its parent,
+// so closing or configuring the child affects the parent as well
+func (l *Gogger) With(keysAndValues ...any) *Gogger {
+	fields := fieldsFromKeysAndValues(keysAndValues)
+	if len(l.fields) > 0 {
+		fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
 	}
+
+	return &Gogger{loggerCore: l.loggerCore, name: l.name, fields: fields, callerSkip: l.callerSkip}
 }
 
-// Log records a message with a logging level
-func (l *Gogger) Log(level LogLevel, message string) {
-	if l.file || l.console {
-		timestamp := getFormattedTimestamp()
-		levelString := getLogLevelString(level)
-		formattedMessage := l.logFormat
+// Close drains the asynchronous queue and closes all sinks when Gogger is destroyed
+func (l *Gogger) Close() {
+	l.mu.Lock()
+	q := l.async
+	l.async = nil
+	l.mu.Unlock()
 
-		formattedMessage = replacePlaceholder(formattedMessage, "%timestamp%", timestamp)
-		formattedMessage = replacePlaceholder(formattedMessage, "%level%", levelString)
-		formattedMessage = replacePlaceholder(formattedMessage, "%message%", message)
+	if q != nil {
+		q.close()
+	}
 
-		if l.file && level >= l.logLevelFile {
-			l.writeLogsFile(formattedMessage)
-		}
-		if l.console && level >= l.logLevelConsole {
-			l.writeLogsToConsole(formattedMessage)
+	for _, sink := range l.allSinks() {
+		if err := sink.Close(); err != nil {
+			fmt.Printf("Error closing sink: %v\n", err)
 		}
+	}
+}
 
-	} else {
-		fmt.Println("No log input in use")
+// Flush waits for the queued records to be written and flushes all sinks
+func (l *Gogger) Flush() {
+	if q := l.getAsyncQueue(); q != nil {
+		q.wait()
+	}
+
+	for _, sink := range l.allSinks() {
+		if err := sink.Flush(); err != nil {
+			fmt.Printf("Error flushing sink: %v\n", err)
+		}
 	}
 }
 
-// Debug writes a debug message
-func (l *Gogger) Debug(debugMessage string) {
-	l.Log(DEBUG, debugMessage)
+// AddSink attaches an additional output to the logger
+func (l *Gogger) AddSink(sink Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sinks = append(l.sinks, sink)
 }
 
-// Info records an informational message
-func (l *Gogger) Info(infoMessage string) {
-	l.Log(INFO, infoMessage)
+// RemoveSink detaches an output previously attached with AddSink without closing it
+func (l *Gogger) RemoveSink(sink Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, s := range l.sinks {
+		if s == sink {
+			l.sinks = append(l.sinks[:i:i], l.sinks[i+1:]...)
+			return
+		}
+	}
 }
 
-// Warning records a warning
-func (l *Gogger) Warning(warningMessage string) {
-	l.Log(WARNING, warningMessage)
+// Log records a message with a logging level
+func (l *Gogger) Log(level LogLevel, message string) {
+	l.log(level, message, nil)
 }
 
-// Error records an error message
-func (l *Gogger) Error(errorMessage string) {
-	l.Log(ERROR, errorMessage)
+// Logf records a message formatted with fmt.Sprintf, formatting is skipped when no output accepts the level
+func (l *Gogger) Logf(level LogLevel, format string, args ...any) {
+	l.logf(level, format, args)
 }
 
-// SetLogLevel sets the logging level for the console and the file
-func (l *Gogger) SetLogLevel(level LogLevel) {
-	l.logLevelConsole = level
-	l.logLevelFile = level
+// LogW records a message with a logging level and structured key/value pairs
+func (l *Gogger) LogW(level LogLevel, message string, keysAndValues ...any) {
+	l.log(level, message, fieldsFromKeysAndValues(keysAndValues))
 }
 
-// SetLogLevelConsole sets the logging level for the console
-func (l *Gogger) SetLogLevelConsole(level LogLevel) {
-	l.logLevelConsole = level
+func (l *Gogger) log(level LogLevel, message string, fields []Field) {
+	l.write(level, message, fields, l.callerSkip)
+	l.terminate(level, message)
 }
 
-// SetLogLevelFile sets the logging level for the file
-func (l *Gogger) SetLogLevelFile(level LogLevel) {
-	l.logLevelFile = level
+// terminate exits the program after FATAL records and panics after PANIC records
+func (l *Gogger) terminate(level LogLevel, message string) {
+	switch level {
+	case FATAL:
+		l.Flush()
+		osExit(1)
+	case PANIC:
+		l.Flush()
+		panic(message)
+	}
 }
 
-// SetLogFormat sets the log format
-func (l *Gogger) SetLogFormat(format string) error {
-	var requiredElements = []string{"%timestamp%", "%level%", "%message%"}
+func (l *Gogger) logf(level LogLevel, format string, args []any) {
+	// FATAL and PANIC always need the message to terminate the program
+	if level != FATAL && level != PANIC && !l.enabled(level) {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	l.write(level, message, nil, l.callerSkip)
+	l.terminate(level, message)
+}
+
+// enabled reports whether the logger name and at least one active output accept the level
+func (l *Gogger) enabled(level LogLevel) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if namedLevel, ok := l.namedLevel(); ok && level < namedLevel {
+		return false
+	}
 
-	isValidFormat := false
-	for _, element := range requiredElements {
-		if strings.Contains(format, element) {
-			isValidFormat = true
-			break
+	if l.file && level >= l.fileSink.Level() {
+		return true
+	}
+	if l.console && level >= l.consoleSink.Level() {
+		return true
+	}
+	for _, sink := range l.sinks {
+		if level >= sink.Level() {
+			return true
 		}
 	}
+	return false
+}
+
+// write builds the record and sends it to the sinks, skip is passed to captureCaller
+func (l *Gogger) write(level LogLevel, message string, fields []Field, skip int) {
+	sinks := l.activeSinks()
+	if len(sinks) == 0 {
+		fmt.Println("No log input in use")
+		return
+	}
 
-	if isValidFormat {
-		l.logFormat = format
-		return nil
+	if !l.namedEnabled(level) {
+		return
 	}
 
-	return fmt.Errorf("invalid log format. The format must contain at least one of the following elements: %%timestamp%%, %%level%%, %%message%%")
+	if len(l.fields) > 0 {
+		fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	}
+
+	record := &Record{
+		Time:    l.now(),
+		Level:   level,
+		Message: message,
+		Fields:  fields,
+		Logger:  l.name,
+	}
+	record.timestampLayout, record.location = l.timestampSettings()
+
+	if needsCaller(sinks) {
+		record.Caller = captureCaller(skip)
+	}
+
+	l.output(record, sinks)
 }
 
-// SetUseConsoleLog sets the use of the console for logging
-func (l *Gogger) SetUseConsoleLog(console bool) {
-	l.console = console
+// output queues the record in asynchronous mode or writes it to the sinks
+func (l *Gogger) output(record *Record, sinks []Sink) {
+	if q := l.getAsyncQueue(); q != nil && q.enqueue(record) {
+		return
+	}
+
+	l.writeRecord(record, sinks)
 }
 
-// SetUseFileLog sets the use of a file for logging
-func (l *Gogger) SetUseFileLog(file bool) {
-	l.file = file
+// dispatch writes the record to the sinks active at the time of the call
+func (l *Gogger) dispatch(record *Record) {
+	l.writeRecord(record, l.activeSinks())
 }
 
-// SetFilename sets the file name, folder path, and maximum number of entries
-func (l *Gogger) SetFilename(filename, pathFolder string, maxEntries int) error {
-	if !isValidFilename(filename) || !isValidPathFolder(pathFolder) || maxEntries <= 0 {
-		return fmt.Errorf("invalid filename, path folder, or max_entries")
+func (l *Gogger) writeRecord(record *Record, sinks []Sink) {
+	for _, sink := range sinks {
+		if record.Level >= sink.Level() {
+			if err := sink.Write(record); err != nil {
+				fmt.Printf("Error writing log: %v\n", err)
+			}
+		}
 	}
+}
 
-	l.filename = filename
-	l.maxEntries = maxEntries
-	l.maxEntriesCounter = maxEntries
-	l.pathFolder = pathFolder
-
-	l.logFileNumber = 0
-	l.logQueueFiles = nil
+// activeSinks returns the enabled built-in sinks followed by the added ones
+func (l *Gogger) activeSinks() []Sink {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
-	err := l.createFolder()
-	if err != nil {
-		return err
+	sinks := make([]Sink, 0, len(l.sinks)+2)
+	if l.file {
+		sinks = append(sinks, l.fileSink)
+	}
+	if l.console {
+		sinks = append(sinks, l.consoleSink)
 	}
-	l.addCurrentFiles()
-	l.deleteAllFiles()
-	l.openFile()
+	return append(sinks, l.sinks...)
+}
 
-	return nil
+// allSinks returns the built-in sinks, enabled or not, followed by the added ones
+func (l *Gogger) allSinks() []Sink {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append([]Sink{l.fileSink, l.consoleSink}, l.sinks...)
 }
 
-// SetMaxEntries sets the maximum number of entries
-func (l *Gogger) SetMaxEntries(maxEntries int) {
-	if l.maxEntries-l.maxEntriesCounter < maxEntries {
-		if l.logFileNumber+1 == l.maxFiles {
-			l.logFileNumber = 0
-		} else {
-			l.logFileNumber++
-		}
-	}
+// Trace writes a trace message
+func (l *Gogger) Trace(traceMessage string) {
+	l.log(TRACE, traceMessage, nil)
+}
 
-	l.maxEntries = maxEntries
-	l.maxEntriesCounter = maxEntries
+// Debug writes a debug message
+func (l *Gogger) Debug(debugMessage string) {
+	l.log(DEBUG, debugMessage, nil)
 }
 
-// SetMaxFiles sets the maximum number of files
-func (l *Gogger) SetMaxFiles(maxFiles int) {
-	l.maxFiles = maxFiles
+// Info records an informational message
+func (l *Gogger) Info(infoMessage string) {
+	l.log(INFO, infoMessage, nil)
 }
 
-func (l *Gogger) openFile() {
-	for {
-		if len(l.logQueueFiles) == 0 {
-			for l.maxFiles < len(l.logQueueFiles)+1 && l.maxFiles != 0 {
-				l.deleteFirstFile()
-			}
-		}
+// Notice records a normal but significant event
+func (l *Gogger) Notice(noticeMessage string) {
+	l.log(NOTICE, noticeMessage, nil)
+}
 
-		if l.maxEntries > l.getCountOfLines() || l.maxEntries == 0 {
-			break
-		}
+// Warning records a warning
+func (l *Gogger) Warning(warningMessage string) {
+	l.log(WARNING, warningMessage, nil)
+}
 
-		l.logQueueFiles = append(l.logQueueFiles, fmt.Sprintf("#%d%s", l.logFileNumber, l.filename))
+// Error records an error message
+func (l *Gogger) Error(errorMessage string) {
+	l.log(ERROR, errorMessage, nil)
+}
 
-		if l.logFileNumber+1 == l.maxFiles {
-			l.logFileNumber = 0
-		} else {
-			l.logFileNumber++
-		}
-	}
+// Critical records a critical condition
+func (l *Gogger) Critical(criticalMessage string) {
+	l.log(CRITICAL, criticalMessage, nil)
+}
 
-	var filePath string
-	if l.pathFolder == "" {
-		filePath = fmt.Sprintf("#%d%s", l.logFileNumber, l.filename)
-	} else {
-		filePath = fmt.Sprintf("%s/#%d%s", l.pathFolder, l.logFileNumber, l.filename)
-	}
-	l.logQueueFiles = append(l.logQueueFiles, filePath)
+// Fatal records a fatal error, flushes all outputs and calls os.Exit(1)
+func (l *Gogger) Fatal(fatalMessage string) {
+	l.log(FATAL, fatalMessage, nil)
+}
 
-	if checkFileExist(filePath) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
+// Panic records a message, flushes all outputs and panics with the message
+func (l *Gogger) Panic(panicMessage string) {
+	l.log(PANIC, panicMessage, nil)
+}
 
-		reader := bufio.NewReader(bytes.NewReader(data))
-		numLines := 0
-		for {
-			_, err := reader.ReadString('\n')
-			if err == io.EOF {
-				break
-			}
-			numLines++
-		}
+// Tracef writes a trace message formatted with fmt.Sprintf
+func (l *Gogger) Tracef(format string, args ...any) {
+	l.logf(TRACE, format, args)
+}
 
-		if numLines >= l.maxEntries {
-			l.deleteFirstFile()
-		} else {
-			l.maxEntriesCounter = l.maxEntries - numLines
-		}
-	}
+// Debugf writes a debug message formatted with fmt.Sprintf
+func (l *Gogger) Debugf(format string, args ...any) {
+	l.logf(DEBUG, format, args)
+}
 
-	var err error
-	if l.fileStream, err = os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
-		fmt.Printf("Error opening file: %v\n", err)
-	}
+// Infof records an informational message formatted with fmt.Sprintf
+func (l *Gogger) Infof(format string, args ...any) {
+	l.logf(INFO, format, args)
 }
 
-func checkFileExist(filename string) bool {
-	_, err := os.Stat(filename)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return false
-		} else {
-			fmt.Println(err)
-		}
-	}
-	return true
+// Noticef records a normal but significant event formatted with fmt.Sprintf
+func (l *Gogger) Noticef(format string, args ...any) {
+	l.logf(NOTICE, format, args)
 }
 
-func (l *Gogger) deleteFirstFile() {
-	if len(l.logQueueFiles) > 0 {
-		filePath := l.logQueueFiles[0]
-		if err := os.Remove(filePath); err != nil {
-			fmt.Printf("Error deleting file: %v\n", err)
-		}
-		l.logQueueFiles = l.logQueueFiles[1:]
-	}
+// Warningf records a warning formatted with fmt.Sprintf
+func (l *Gogger) Warningf(format string, args ...any) {
+	l.logf(WARNING, format, args)
 }
 
-func (l *Gogger) deleteAllFiles() {
-	for len(l.logQueueFiles) > 0 {
-		l.deleteFirstFile()
-	}
+// Errorf records an error message formatted with fmt.Sprintf
+func (l *Gogger) Errorf(format string, args ...any) {
+	l.logf(ERROR, format, args)
 }
 
-func (l *Gogger) writeLogsToFile(formattedMessage string) {
-	if l.fileStream == nil {
-		l.openFile()
-	}
+// Criticalf records a critical condition formatted with fmt.Sprintf
+func (l *Gogger) Criticalf(format string, args ...any) {
+	l.logf(CRITICAL, format, args)
+}
 
-	if _, err := io.WriteString(l.fileStream, formattedMessage+"\n"); err != nil {
-		fmt.Printf("Error writing to file: %v\n", err)
-	}
+// Fatalf records a fatal error formatted with fmt.Sprintf, flushes all outputs and calls os.Exit(1)
+func (l *Gogger) Fatalf(format string, args ...any) {
+	l.logf(FATAL, format, args)
 }
 
-func (l *Gogger) writeLogsFile(formattedMessage string) {
-	if l.maxEntriesCounter > 0 || l.maxEntries == 0 {
-		l.maxEntriesCounter--
-		l.writeLogsToFile(formattedMessage)
-	} else {
-		l.maxEntriesCounter = l.maxEntries
-		if l.fileStream != nil {
-			err := l.fileStream.Close()
-			if err != nil {
-				return
-			}
-		}
+// Panicf records a message formatted with fmt.Sprintf, flushes all outputs and panics with the message
+func (l *Gogger) Panicf(format string, args ...any) {
+	l.logf(PANIC, format, args)
+}
 
-		if len(l.logQueueFiles) >= l.maxFiles {
-			l.deleteFirstFile()
-		}
+// TraceW writes a trace message with structured key/value pairs
+func (l *Gogger) TraceW(traceMessage string, keysAndValues ...any) {
+	l.log(TRACE, traceMessage, fieldsFromKeysAndValues(keysAndValues))
+}
 
-		l.logFileNumber++
+// DebugW writes a debug message with structured key/value pairs
+func (l *Gogger) DebugW(debugMessage string, keysAndValues ...any) {
+	l.log(DEBUG, debugMessage, fieldsFromKeysAndValues(keysAndValues))
+}
 
-		l.openFile()
-		l.writeLogsToFile(formattedMessage)
-	}
+// InfoW records an informational message with structured key/value pairs
+func (l *Gogger) InfoW(infoMessage string, keysAndValues ...any) {
+	l.log(INFO, infoMessage, fieldsFromKeysAndValues(keysAndValues))
 }
 
-func (l *Gogger) writeLogsToConsole(formattedMessage string) {
-	fmt.Println(formattedMessage)
+// NoticeW records a normal but significant event with structured key/value pairs
+func (l *Gogger) NoticeW(noticeMessage string, keysAndValues ...any) {
+	l.log(NOTICE, noticeMessage, fieldsFromKeysAndValues(keysAndValues))
 }
 
-func (l *Gogger) addCurrentFiles() {
-	var folder string
-	if l.pathFolder == "" {
-		folder = "."
-	} else {
-		folder = l.pathFolder
-	}
+// WarningW records a warning with structured key/value pairs
+func (l *Gogger) WarningW(warningMessage string, keysAndValues ...any) {
+	l.log(WARNING, warningMessage, fieldsFromKeysAndValues(keysAndValues))
+}
 
-	files, err := filepath.Glob(filepath.Join(folder, fmt.Sprintf("*%s", l.filename)))
-	if err != nil {
-		fmt.Printf("Error reading files in directory: %v\n", err)
-		return
-	}
+// ErrorW records an error message with structured key/value pairs
+func (l *Gogger) ErrorW(errorMessage string, keysAndValues ...any) {
+	l.log(ERROR, errorMessage, fieldsFromKeysAndValues(keysAndValues))
+}
 
-	l.logQueueFiles = append(l.logQueueFiles, files...)
+// CriticalW records a critical condition with structured key/value pairs
+func (l *Gogger) CriticalW(criticalMessage string, keysAndValues ...any) {
+	l.log(CRITICAL, criticalMessage, fieldsFromKeysAndValues(keysAndValues))
 }
 
-func (l *Gogger) createFolder() error {
-	if l.pathFolder != "" {
-		if _, err := os.Stat(l.pathFolder); os.IsNotExist(err) {
-			if err := os.Mkdir(l.pathFolder, 0755); err != nil {
-				return fmt.Errorf("failed to create folder: %v", err)
-			}
-		}
-	}
-	return nil
+// FatalW records a fatal error with structured key/value pairs, flushes all outputs and calls os.Exit(1)
+func (l *Gogger) FatalW(fatalMessage string, keysAndValues ...any) {
+	l.log(FATAL, fatalMessage, fieldsFromKeysAndValues(keysAndValues))
+}
+
+// PanicW records a message with structured key/value pairs, flushes all outputs and panics with the message
+func (l *Gogger) PanicW(panicMessage string, keysAndValues ...any) {
+	l.log(PANIC, panicMessage, fieldsFromKeysAndValues(keysAndValues))
 }
 
-func (l *Gogger) getCountOfLines() int {
+// SetLogLevel sets the logging level for the console and the file
+func (l *Gogger) SetLogLevel(level LogLevel) {
+	l.consoleSink.SetLevel(level)
+	l.fileSink.SetLevel(level)
+}
 
-	filePath := fmt.Sprintf("#%d%s", l.logFileNumber, l.filename)
+// SetLogLevelConsole sets the logging level for the console
+func (l *Gogger) SetLogLevelConsole(level LogLevel) {
+	l.consoleSink.SetLevel(level)
+}
+
+// SetLogLevelFile sets the logging level for the file
+func (l *Gogger) SetLogLevelFile(level LogLevel) {
+	l.fileSink.SetLevel(level)
+}
 
-	file, err := os.Open(filePath)
+// SetLogFormat sets the log format for the console and the file
+func (l *Gogger) SetLogFormat(format string) error {
+	formatter, err := NewTextFormatter(format)
 	if err != nil {
-		return 0
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-
-	var lineCount int
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lineCount++
+		return err
 	}
 
-	return lineCount
+	l.consoleSink.SetFormatter(formatter)
+	l.fileSink.SetFormatter(formatter)
+	return nil
 }
 
-func isValidFilename(filename string) bool {
-	pattern := regexp.MustCompile(`[a-zA-Z0-9_]+\.(txt|log)`)
-	return pattern.MatchString(filename)
+// SetFormatter sets the formatter for the console and the file
+func (l *Gogger) SetFormatter(formatter Formatter) {
+	l.consoleSink.SetFormatter(formatter)
+	l.fileSink.SetFormatter(formatter)
 }
 
-func isValidPathFolder(pathFolder string) bool {
-	if pathFolder == "" {
-		return true
-	}
-	pattern := regexp.MustCompile(`.+[a-zA-Z0-9_]`)
-	return pattern.MatchString(pathFolder)
+// SetFormatterConsole sets the formatter for the console
+func (l *Gogger) SetFormatterConsole(formatter Formatter) {
+	l.consoleSink.SetFormatter(formatter)
 }
 
-func getFormattedTimestamp() string {
-	return time.Now().Format("02-01-2006 15:04:05")
+// SetFormatterFile sets the formatter for the file
+func (l *Gogger) SetFormatterFile(formatter Formatter) {
+	l.fileSink.SetFormatter(formatter)
 }
 
-func getLogLevelString(level LogLevel) string {
-	switch level {
-	case DEBUG:
-		return "DEBUG"
-	case INFO:
-		return "INFO"
-	case WARNING:
-		return "WARNING"
-	default: // ERROR
-		return "ERROR"
-	}
+// SetUseConsoleLog sets the use of the console for logging
+func (l *Gogger) SetUseConsoleLog(console bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.console = console
 }
 
-func replacePlaceholder(format, placeholder, value string) string {
-	return strings.ReplaceAll(format, placeholder, value)
+// SetUseConsoleColors enables or disables colored level names in the console, overriding terminal detection
+func (l *Gogger) SetUseConsoleColors(colors bool) {
+	l.consoleSink.SetColors(colors)
+}
+
+// SetUseFileLog sets the use of a file for logging
+func (l *Gogger) SetUseFileLog(file bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.file = file
+}
+
+// SetFilename sets the file name, folder path, and maximum number of entries
+func (l *Gogger) SetFilename(filename, pathFolder string, maxEntries int) error {
+	return l.fileSink.SetFilename(filename, pathFolder, maxEntries)
+}
+
+// SetMaxEntries sets the maximum number of entries
+func (l *Gogger) SetMaxEntries(maxEntries int) {
+	l.fileSink.SetMaxEntries(maxEntries)
+}
+
+// SetMaxBytes sets the maximum size of one file in bytes, 0 disables size-based rotation
+func (l *Gogger) SetMaxBytes(maxBytes int64) {
+	l.fileSink.SetMaxBytes(maxBytes)
+}
+
+// SetRotationPolicy sets time-based rotation of the log files
+func (l *Gogger) SetRotationPolicy(policy RotationPolicy) error {
+	return l.fileSink.SetRotationPolicy(policy)
+}
+
+// SetCompression sets the compression of rotated log files
+func (l *Gogger) SetCompression(compression Compression) error {
+	return l.fileSink.SetCompression(compression)
+}
+
+// SetMaxAge sets the maximum age of log files, expired files are deleted immediately and at every rotation
+func (l *Gogger) SetMaxAge(maxAge time.Duration) {
+	l.fileSink.SetMaxAge(maxAge)
+}
+
+// SetMaxFiles sets the maximum number of files
+func (l *Gogger) SetMaxFiles(maxFiles int) {
+	l.fileSink.SetMaxFiles(maxFiles)
 }
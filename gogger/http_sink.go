@@ -0,0 +1,336 @@
+package gogger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// httpBatch is a group of records posted in one request
+type httpBatch struct {
+	records []*Record
+	body    []byte
+}
+
+// HTTPSink posts records in batches as JSON lines to an HTTP endpoint.
+// A batch is sent when it reaches the count or byte limit or when its oldest record waited for the latency limit.
+// Failed requests are retried with exponential backoff, then the batch is written to the spill sink.
+// It is safe for concurrent use
+type HTTPSink struct {
+	sinkBase
+	url     string
+	client  *http.Client
+	headers http.Header
+
+	maxCount   int
+	maxBytes   int
+	maxLatency time.Duration
+
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	spill Sink
+
+	batch   httpBatch
+	batchID int
+	timer   *time.Timer
+	batches chan httpBatch
+	done    chan struct{}
+	closed  bool
+	wg      sync.WaitGroup
+
+	inFlightMu   sync.Mutex
+	inFlightCond *sync.Cond
+	inFlight     int
+}
+
+// NewHTTPSink creates a sink posting records to the http or https URL.
+// Records are encoded with JSONFormatter unless another formatter is set
+func NewHTTPSink(endpoint string) (*HTTPSink, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid HTTP endpoint %q", endpoint)
+	}
+
+	s := &HTTPSink{
+		sinkBase: sinkBase{
+			level:     DEBUG,
+			formatter: NewJSONFormatter(),
+		},
+		url:            endpoint,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		headers:        http.Header{"Content-Type": []string{"application/x-ndjson"}},
+		maxCount:       100,
+		maxBytes:       1 << 20,
+		maxLatency:     time.Second,
+		maxRetries:     5,
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     10 * time.Second,
+		batches:        make(chan httpBatch, 16),
+		done:           make(chan struct{}),
+	}
+	s.inFlightCond = sync.NewCond(&s.inFlightMu)
+
+	s.wg.Add(1)
+	go s.run()
+
+	return s, nil
+}
+
+// Write adds the record to the current batch and sends the batch when it is full
+func (s *HTTPSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("HTTP sink is closed")
+	}
+
+	line := s.format(record)
+	if len(s.batch.records) > 0 && len(s.batch.body)+len(line)+1 > s.maxBytes {
+		s.sendBatch()
+	}
+
+	s.batch.records = append(s.batch.records, record)
+	s.batch.body = append(s.batch.body, line...)
+	s.batch.body = append(s.batch.body, '\n')
+
+	if len(s.batch.records) >= s.maxCount || len(s.batch.body) >= s.maxBytes {
+		s.sendBatch()
+	} else if len(s.batch.records) == 1 {
+		batchID := s.batchID
+		s.timer = time.AfterFunc(s.maxLatency, func() { s.sendExpired(batchID) })
+	}
+
+	return nil
+}
+
+// Flush sends the current batch and waits until all batches are posted or spilled
+func (s *HTTPSink) Flush() error {
+	s.mu.Lock()
+	s.sendBatch()
+	s.mu.Unlock()
+
+	s.inFlightMu.Lock()
+	for s.inFlight > 0 {
+		s.inFlightCond.Wait()
+	}
+	s.inFlightMu.Unlock()
+
+	return nil
+}
+
+// Close sends the remaining records and stops the sink. Retries are cut short and their batches spilled.
+// The spill sink is not closed
+func (s *HTTPSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.sendBatch()
+	s.closed = true
+	close(s.batches)
+	s.mu.Unlock()
+
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+// SetHeader sets a header sent with every request, such as an API key
+func (s *HTTPSink) SetHeader(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers.Set(key, value)
+}
+
+// SetBasicAuth sets the user name and password sent with every request
+func (s *HTTPSink) SetBasicAuth(username, password string) {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	s.SetHeader("Authorization", "Basic "+credentials)
+}
+
+// SetBearerToken sets the token sent in the Authorization header of every request
+func (s *HTTPSink) SetBearerToken(token string) {
+	s.SetHeader("Authorization", "Bearer "+token)
+}
+
+// SetClient sets the HTTP client used to post batches, with its timeout and transport
+func (s *HTTPSink) SetClient(client *http.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client = client
+}
+
+// SetBatchLimits sets the maximum number of records, size in bytes and waiting time of a batch.
+// Defaults are 100 records, 1 MiB and 1 second
+func (s *HTTPSink) SetBatchLimits(maxCount, maxBytes int, maxLatency time.Duration) error {
+	if maxCount <= 0 || maxBytes <= 0 || maxLatency <= 0 {
+		return fmt.Errorf("invalid batch limits. The limits must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxCount = maxCount
+	s.maxBytes = maxBytes
+	s.maxLatency = maxLatency
+	return nil
+}
+
+// SetRetry sets the number of retries of a failed request and the backoff doubling between them.
+// Defaults are 5 retries with a backoff from 100 milliseconds to 10 seconds
+func (s *HTTPSink) SetRetry(maxRetries int, initialBackoff, maxBackoff time.Duration) error {
+	if maxRetries < 0 || initialBackoff <= 0 || maxBackoff < initialBackoff {
+		return fmt.Errorf("invalid retry settings")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxRetries = maxRetries
+	s.initialBackoff = initialBackoff
+	s.maxBackoff = maxBackoff
+	return nil
+}
+
+// SetSpill sets the sink receiving the records of batches that could not be posted,
+// usually a FileSink. Without it such records are dropped
+func (s *HTTPSink) SetSpill(spill Sink) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spill = spill
+}
+
+// sendExpired sends the batch when its latency limit is reached, unless it was already sent
+func (s *HTTPSink) sendExpired(batchID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed && batchID == s.batchID {
+		s.sendBatch()
+	}
+}
+
+// sendBatch hands the current batch to the sender, spilling it right away when the sender is busy.
+// The caller must hold s.mu
+func (s *HTTPSink) sendBatch() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if len(s.batch.records) == 0 {
+		return
+	}
+
+	batch := s.batch
+	s.batch = httpBatch{}
+	s.batchID++
+
+	s.inFlightMu.Lock()
+	s.inFlight++
+	s.inFlightMu.Unlock()
+
+	select {
+	case s.batches <- batch:
+	default:
+		s.spillBatch(batch, s.spill)
+		s.release()
+	}
+}
+
+func (s *HTTPSink) run() {
+	defer s.wg.Done()
+
+	for batch := range s.batches {
+		if err := s.post(batch); err != nil {
+			fmt.Printf("Error sending logs: %v\n", err)
+
+			s.mu.Lock()
+			spill := s.spill
+			s.mu.Unlock()
+			s.spillBatch(batch, spill)
+		}
+		s.release()
+	}
+}
+
+// post sends the batch, retrying with exponential backoff until it is accepted or the retries run out
+func (s *HTTPSink) post(batch httpBatch) error {
+	s.mu.Lock()
+	client, headers := s.client, s.headers.Clone()
+	maxRetries, backoff, maxBackoff := s.maxRetries, s.initialBackoff, s.maxBackoff
+	s.mu.Unlock()
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = s.postOnce(client, headers, batch.body); err == nil || !retry || attempt >= maxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-s.done:
+			return err
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// postOnce sends one request, retry reports whether the failure may be temporary
+func (s *HTTPSink) postOnce(client *http.Client, headers http.Header, body []byte) (retry bool, err error) {
+	request, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header = headers
+
+	response, err := client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry = response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("HTTP endpoint returned %s", response.Status)
+}
+
+func (s *HTTPSink) spillBatch(batch httpBatch, spill Sink) {
+	if spill == nil {
+		return
+	}
+	level := spill.Level()
+	for _, record := range batch.records {
+		if record.Level < level {
+			continue
+		}
+		if err := spill.Write(record); err != nil {
+			fmt.Printf("Error spilling log: %v\n", err)
+		}
+	}
+}
+
+func (s *HTTPSink) release() {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+
+	s.inFlight--
+	if s.inFlight == 0 {
+		s.inFlightCond.Broadcast()
+	}
+}
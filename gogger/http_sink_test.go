@@ -0,0 +1,276 @@
+package gogger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// httpTestServer records the JSON lines of every request and answers with the statuses in order, then 200
+type httpTestServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests [][]string
+	headers  []http.Header
+	received chan struct{}
+}
+
+func newHTTPTestServer(t *testing.T, statuses ...int) *httpTestServer {
+	s := &httpTestServer{statuses: statuses, received: make(chan struct{}, 100)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var entry map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Errorf("Request contains an invalid JSON line %q: %v", scanner.Text(), err)
+			}
+			lines = append(lines, entry["message"].(string))
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, lines)
+		s.headers = append(s.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+		s.received <- struct{}{}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *httpTestServer) batches() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.requests...)
+}
+
+func newTestHTTPSink(t *testing.T, url string) *HTTPSink {
+	sink, err := NewHTTPSink(url)
+	if err != nil {
+		t.Fatalf("NewHTTPSink returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = sink.Close() })
+	return sink
+}
+
+func writeHTTPRecords(t *testing.T, sink *HTTPSink, messages ...string) {
+	for _, message := range messages {
+		if err := sink.Write(&Record{Time: time.Now(), Level: INFO, Message: message}); err != nil {
+			t.Fatalf("Write returned unexpected error: %v", err)
+		}
+	}
+}
+
+func TestHTTPSinkBatchByCount(t *testing.T) {
+	server := newHTTPTestServer(t)
+	sink := newTestHTTPSink(t, server.URL)
+	if err := sink.SetBatchLimits(3, 1<<20, time.Hour); err != nil {
+		t.Fatalf("SetBatchLimits returned unexpected error: %v", err)
+	}
+	sink.SetBasicAuth("user", "secret")
+	sink.SetHeader("X-Source", "edge")
+
+	writeHTTPRecords(t, sink, "1", "2", "3", "4", "5", "6", "7")
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush returned unexpected error: %v", err)
+	}
+
+	batches := server.batches()
+	expected := [][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7"}}
+	if len(batches) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), batches)
+	}
+	for i := range expected {
+		if strings.Join(batches[i], ",") != strings.Join(expected[i], ",") {
+			t.Errorf("Request %d: expected %v, got %v", i, expected[i], batches[i])
+		}
+	}
+
+	header := server.headers[0]
+	if header.Get("Authorization") != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("Unexpected Authorization header %q", header.Get("Authorization"))
+	}
+	if header.Get("X-Source") != "edge" || header.Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("Unexpected headers %v", header)
+	}
+}
+
+func TestHTTPSinkBatchByBytes(t *testing.T) {
+	server := newHTTPTestServer(t)
+	sink := newTestHTTPSink(t, server.URL)
+	formatter, err := NewTextFormatter(`{"message":"%message%"}`)
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	sink.SetFormatter(formatter)
+
+	// Every line takes 16 bytes with the newline, so a batch holds at most two of them
+	if err := sink.SetBatchLimits(100, 40, time.Hour); err != nil {
+		t.Fatalf("SetBatchLimits returned unexpected error: %v", err)
+	}
+
+	writeHTTPRecords(t, sink, "a", "b", "c", "d", "e")
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush returned unexpected error: %v", err)
+	}
+
+	batches := server.batches()
+	expected := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if len(batches) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), batches)
+	}
+	for i := range expected {
+		if strings.Join(batches[i], ",") != strings.Join(expected[i], ",") {
+			t.Errorf("Request %d: expected %v, got %v", i, expected[i], batches[i])
+		}
+	}
+}
+
+func TestHTTPSinkBatchByLatency(t *testing.T) {
+	server := newHTTPTestServer(t)
+	sink := newTestHTTPSink(t, server.URL)
+	if err := sink.SetBatchLimits(100, 1<<20, 20*time.Millisecond); err != nil {
+		t.Fatalf("SetBatchLimits returned unexpected error: %v", err)
+	}
+
+	writeHTTPRecords(t, sink, "delayed")
+
+	select {
+	case <-server.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Batch was not sent after the latency limit")
+	}
+	if batches := server.batches(); len(batches) != 1 || batches[0][0] != "delayed" {
+		t.Errorf("Unexpected requests %v", batches)
+	}
+}
+
+func TestHTTPSinkRetry(t *testing.T) {
+	server := newHTTPTestServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	sink := newTestHTTPSink(t, server.URL)
+	if err := sink.SetRetry(3, time.Millisecond, 4*time.Millisecond); err != nil {
+		t.Fatalf("SetRetry returned unexpected error: %v", err)
+	}
+
+	writeHTTPRecords(t, sink, "retried")
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush returned unexpected error: %v", err)
+	}
+
+	if batches := server.batches(); len(batches) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(batches))
+	}
+}
+
+func TestHTTPSinkSpill(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+	}{
+		{"Endpoint down", []int{500, 500, 500}, 3},
+		{"Rejected batch", []int{400}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newHTTPTestServer(t, tt.statuses...)
+			sink := newTestHTTPSink(t, server.URL)
+			if err := sink.SetRetry(2, time.Millisecond, time.Millisecond); err != nil {
+				t.Fatalf("SetRetry returned unexpected error: %v", err)
+			}
+
+			tempDir := t.TempDir()
+			sink.SetSpill(newTestFileSink(t, tempDir, 100, 5))
+
+			writeHTTPRecords(t, sink, "first", "second")
+			if err := sink.Flush(); err != nil {
+				t.Fatalf("Flush returned unexpected error: %v", err)
+			}
+
+			if batches := server.batches(); len(batches) != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, len(batches))
+			}
+
+			content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+			if err != nil {
+				t.Fatalf("Failed to read spill file: %v", err)
+			}
+			if string(content) != "first\nsecond\n" {
+				t.Errorf("Expected spilled records, got %q", content)
+			}
+		})
+	}
+}
+
+func TestHTTPSinkSpillLevel(t *testing.T) {
+	server := newHTTPTestServer(t, http.StatusBadRequest)
+	sink := newTestHTTPSink(t, server.URL)
+
+	tempDir := t.TempDir()
+	spill := newTestFileSink(t, tempDir, 100, 5)
+	spill.SetLevel(WARNING)
+	sink.SetSpill(spill)
+
+	writeHTTPRecords(t, sink, "info")
+	if err := sink.Write(&Record{Time: time.Now(), Level: ERROR, Message: "error"}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush returned unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read spill file: %v", err)
+	}
+	if string(content) != "error\n" {
+		t.Errorf("Expected only the record above the spill level, got %q", content)
+	}
+}
+
+func TestHTTPSinkClose(t *testing.T) {
+	server := newHTTPTestServer(t)
+	sink := newTestHTTPSink(t, server.URL)
+
+	logger := newTestLogger(t, sink)
+	logger.Info("sent on close")
+	logger.Close()
+
+	if batches := server.batches(); len(batches) != 1 || batches[0][0] != "sent on close" {
+		t.Errorf("Unexpected requests %v", batches)
+	}
+	if err := sink.Write(&Record{Level: INFO, Message: "late"}); err == nil {
+		t.Error("Write should return an error after Close")
+	}
+}
+
+func TestHTTPSinkValidation(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:8080", "ftp://example.com/logs"} {
+		if _, err := NewHTTPSink(endpoint); err == nil {
+			t.Errorf("NewHTTPSink(%q) should return an error", endpoint)
+		}
+	}
+
+	sink := newTestHTTPSink(t, "http://127.0.0.1:1/logs")
+	if err := sink.SetBatchLimits(0, 100, time.Second); err == nil {
+		t.Error("SetBatchLimits should return an error for a zero count")
+	}
+	if err := sink.SetRetry(3, time.Second, time.Millisecond); err == nil {
+		t.Error("SetRetry should return an error when the maximum backoff is below the initial one")
+	}
+}
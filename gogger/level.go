@@ -0,0 +1,140 @@
+package gogger
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LogLevel enumeration type for logging levels.
+// The built-in levels are spaced by 10 so custom levels can sit between them.
+// DEBUG stays 0 so the zero value of LogLevel is still DEBUG, TRACE is below it
+type LogLevel int
+
+const (
+	TRACE    LogLevel = -10
+	DEBUG    LogLevel = 0
+	INFO     LogLevel = 10
+	NOTICE   LogLevel = 20
+	WARNING  LogLevel = 30
+	ERROR    LogLevel = 40
+	CRITICAL LogLevel = 50
+	FATAL    LogLevel = 60
+	PANIC    LogLevel = 70
+)
+
+// levelInfo describes a registered level
+type levelInfo struct {
+	name  string
+	color string
+}
+
+var (
+	levelsMu sync.RWMutex
+	levels   = map[LogLevel]levelInfo{
+		TRACE:    {name: "TRACE", color: "90"},
+		DEBUG:    {name: "DEBUG", color: "36"},
+		INFO:     {name: "INFO", color: "32"},
+		NOTICE:   {name: "NOTICE", color: "34"},
+		WARNING:  {name: "WARNING", color: "33"},
+		ERROR:    {name: "ERROR", color: "31"},
+		CRITICAL: {name: "CRITICAL", color: "1;31"},
+		FATAL:    {name: "FATAL", color: "1;35"},
+		PANIC:    {name: "PANIC", color: "1;37;41"},
+	}
+)
+
+var (
+	levelNamePattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	levelColorPattern = regexp.MustCompile(`^[0-9]+(;[0-9]+)*$`)
+)
+
+// RegisterLevel adds a custom level with a display name and a numeric severity.
+// color is an optional ANSI SGR code such as "35" or "1;31" used for the level name in colored console output
+func RegisterLevel(name string, severity int, color string) (LogLevel, error) {
+	if !levelNamePattern.MatchString(name) {
+		return 0, fmt.Errorf("invalid level name %q", name)
+	}
+	if color != "" && !levelColorPattern.MatchString(color) {
+		return 0, fmt.Errorf("invalid level color %q", color)
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	level := LogLevel(severity)
+	if existing, ok := levels[level]; ok {
+		return 0, fmt.Errorf("severity %d is already used by level %s", severity, existing.name)
+	}
+	for _, info := range levels {
+		if strings.EqualFold(info.name, name) {
+			return 0, fmt.Errorf("level %s is already registered", name)
+		}
+	}
+
+	levels[level] = levelInfo{name: strings.ToUpper(name), color: color}
+	return level, nil
+}
+
+// SetLevelColor sets the ANSI SGR code such as "33" or "1;31" used for the level name in colored console output.
+// An empty color prints the level name uncolored
+func SetLevelColor(level LogLevel, color string) error {
+	if color != "" && !levelColorPattern.MatchString(color) {
+		return fmt.Errorf("invalid level color %q", color)
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	info, ok := levels[level]
+	if !ok {
+		return fmt.Errorf("unknown log level %d", int(level))
+	}
+	info.color = color
+	levels[level] = info
+	return nil
+}
+
+// ParseLogLevel returns the level with the given name, case-insensitive, or numeric severity
+func ParseLogLevel(name string) (LogLevel, error) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	for level, info := range levels {
+		if strings.EqualFold(info.name, name) {
+			return level, nil
+		}
+	}
+
+	if severity, err := strconv.Atoi(name); err == nil {
+		return LogLevel(severity), nil
+	}
+
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// String returns the display name of the level
+func (level LogLevel) String() string {
+	return getLogLevelString(level)
+}
+
+func getLogLevelString(level LogLevel) string {
+	levelsMu.RLock()
+	info, ok := levels[level]
+	levelsMu.RUnlock()
+
+	if ok {
+		return info.name
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(level))
+}
+
+// getLogLevelColor returns the console color of the level, empty when it has none
+func getLogLevelColor(level LogLevel) string {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	return levels[level].color
+}
@@ -0,0 +1,145 @@
+package gogger
+
+import (
+	"strings"
+	"testing"
+)
+
+// registerTestLevel registers a level and removes it from the registry when the test ends
+func registerTestLevel(t *testing.T, name string, severity int, color string) (LogLevel, error) {
+	level, err := RegisterLevel(name, severity, color)
+	if err == nil {
+		t.Cleanup(func() {
+			levelsMu.Lock()
+			defer levelsMu.Unlock()
+			delete(levels, level)
+		})
+	}
+	return level, err
+}
+
+func TestLevelZeroValue(t *testing.T) {
+	var level LogLevel
+	if level != DEBUG {
+		t.Errorf("Expected the zero value to be DEBUG, got %s", level)
+	}
+	if TRACE >= DEBUG {
+		t.Errorf("Expected TRACE below DEBUG, got %d", TRACE)
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	audit, err := registerTestLevel(t, "Audit", 35, "35")
+	if err != nil {
+		t.Fatalf("RegisterLevel returned unexpected error: %v", err)
+	}
+
+	if audit.String() != "AUDIT" {
+		t.Errorf("Expected level name AUDIT, got %s", audit)
+	}
+	if audit <= WARNING || audit >= ERROR {
+		t.Errorf("Expected AUDIT between WARNING and ERROR, got %d", audit)
+	}
+
+	tests := []struct {
+		name     string
+		level    string
+		severity int
+		color    string
+	}{
+		{"Duplicate name", "audit", 36, ""},
+		{"Duplicate severity", "SECURITY", int(ERROR), ""},
+		{"Invalid name", "NOT A LEVEL", 37, ""},
+		{"Invalid color", "SECURITY", 37, "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RegisterLevel(tt.level, tt.severity, tt.color); err == nil {
+				t.Errorf("RegisterLevel(%q, %d, %q) should return an error", tt.level, tt.severity, tt.color)
+			}
+		})
+	}
+
+	formatter, err := NewTextFormatter("[%level%] %message%")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	if line := formatter.Format(&Record{Level: audit, Message: "Audit message"}); line != "[AUDIT] Audit message" {
+		t.Errorf("Unexpected output: %q", line)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    LogLevel
+		wantErr bool
+	}{
+		{"warning", WARNING, false},
+		{"TRACE", TRACE, false},
+		{"Critical", CRITICAL, false},
+		{"25", LogLevel(25), false},
+		{"unknown", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLogLevel(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLogLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelColor(t *testing.T) {
+	security, err := registerTestLevel(t, "SECURITY", 55, "1;31")
+	if err != nil {
+		t.Fatalf("RegisterLevel returned unexpected error: %v", err)
+	}
+
+	sink, buf := newBufferTestSink(t, "[%level%] %message%", DEBUG)
+
+	record := &Record{Level: security, Message: "Access denied"}
+	if err := sink.Write(record); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	sink.SetColors(true)
+	if err := sink.Write(record); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if lines[0] != "[SECURITY] Access denied" {
+		t.Errorf("Unexpected uncolored line: %q", lines[0])
+	}
+	if lines[1] != "[\x1b[1;31mSECURITY\x1b[0m] Access denied" {
+		t.Errorf("Unexpected colored line: %q", lines[1])
+	}
+}
+
+func TestSetLevelColor(t *testing.T) {
+	if color := getLogLevelColor(INFO); color != "32" {
+		t.Errorf("Expected default INFO color 32, got %q", color)
+	}
+
+	if err := SetLevelColor(INFO, "1;32"); err != nil {
+		t.Fatalf("SetLevelColor returned unexpected error: %v", err)
+	}
+	defer SetLevelColor(INFO, "32")
+
+	if color := getLogLevelColor(INFO); color != "1;32" {
+		t.Errorf("Expected INFO color 1;32, got %q", color)
+	}
+
+	if err := SetLevelColor(INFO, "green"); err == nil {
+		t.Error("SetLevelColor should return an error for an invalid color")
+	}
+	if err := SetLevelColor(LogLevel(12345), "32"); err == nil {
+		t.Error("SetLevelColor should return an error for an unknown level")
+	}
+}
@@ -0,0 +1,116 @@
+package gogger
+
+import (
+	"strings"
+)
+
+// Name returns the dot-separated name of the logger, empty for the root logger
+func (l *Gogger) Name() string {
+	return l.name
+}
+
+// GetLogger returns the logger registered under the dot-separated name, such as "http.router",
+// creating it on first use. Named loggers share the outputs of the root logger
+func (l *Gogger) GetLogger(name string) *Gogger {
+	name = normalizeLoggerName(name)
+	if name == "" {
+		return &Gogger{loggerCore: l.loggerCore}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.named == nil {
+		l.named = make(map[string]*Gogger)
+	}
+	if named, ok := l.named[name]; ok {
+		return named
+	}
+
+	named := &Gogger{loggerCore: l.loggerCore, name: name}
+	l.named[name] = named
+	return named
+}
+
+// Named returns the logger below the current one, Logger.Named("http").Named("router")
+// is the same logger as Logger.GetLogger("http.router"). Fields of the current logger are kept
+func (l *Gogger) Named(name string) *Gogger {
+	name = normalizeLoggerName(name)
+	if name == "" {
+		return l
+	}
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+
+	named := l.GetLogger(name)
+	if len(l.fields) == 0 && l.callerSkip == 0 {
+		return named
+	}
+	return &Gogger{loggerCore: l.loggerCore, name: named.name, fields: l.fields, callerSkip: l.callerSkip}
+}
+
+// SetNamedLevel sets the minimum level of the named logger and of the loggers below it
+// that have no level of their own. An empty name sets the level of all loggers.
+// The level is checked before the levels of the outputs, so outputs must accept it as well
+func (l *Gogger) SetNamedLevel(name string, level LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.namedLevels == nil {
+		l.namedLevels = make(map[string]LogLevel)
+	}
+	l.namedLevels[normalizeLoggerName(name)] = level
+}
+
+// ResetNamedLevel removes the level of the named logger so it inherits the level of its parent
+func (l *Gogger) ResetNamedLevel(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.namedLevels, normalizeLoggerName(name))
+}
+
+// namedLevel returns the level of the closest named logger with a level, the caller must hold l.mu
+func (l *Gogger) namedLevel() (LogLevel, bool) {
+	if len(l.namedLevels) == 0 {
+		return 0, false
+	}
+
+	name := l.name
+	for {
+		if level, ok := l.namedLevels[name]; ok {
+			return level, true
+		}
+		if name == "" {
+			return 0, false
+		}
+
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			name = name[:i]
+		} else {
+			name = ""
+		}
+	}
+}
+
+// namedEnabled reports whether the level of the logger name accepts the level
+func (l *Gogger) namedEnabled(level LogLevel) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	namedLevel, ok := l.namedLevel()
+	return !ok || level >= namedLevel
+}
+
+// normalizeLoggerName removes empty segments from a dot-separated name
+func normalizeLoggerName(name string) string {
+	segments := strings.Split(name, ".")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			kept = append(kept, segment)
+		}
+	}
+	return strings.Join(kept, ".")
+}
@@ -0,0 +1,82 @@
+package gogger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetLogger(t *testing.T) {
+	logger, _ := newBufferTestLogger(t, "%logger% [%level%] %message%", TRACE)
+
+	router := logger.GetLogger("http.router")
+	if router != logger.GetLogger("http.router") {
+		t.Error("GetLogger returned different instances for the same name")
+	}
+	if router != logger.Named("http").Named("router") {
+		t.Error("Named does not resolve to the registered logger")
+	}
+	if router.Name() != "http.router" {
+		t.Errorf("Expected name http.router, got %s", router.Name())
+	}
+	if logger.GetLogger(".http..router.") != router {
+		t.Error("Expected empty name segments to be ignored")
+	}
+	if router.loggerCore != logger.loggerCore {
+		t.Error("Named logger does not share the outputs of the root logger")
+	}
+}
+
+func TestNamedLevels(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%logger% [%level%] %message%", TRACE)
+
+	logger.SetNamedLevel("", INFO)
+	logger.SetNamedLevel("db", DEBUG)
+	logger.SetNamedLevel("http", ERROR)
+	logger.SetNamedLevel("http.router", WARNING)
+
+	db := logger.GetLogger("db")
+	dbPool := db.Named("pool")
+	http := logger.GetLogger("http")
+	router := logger.GetLogger("http.router")
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	db.Debug("db debug")
+	dbPool.Debugf("pool %s", "debug")
+	dbPool.Trace("pool trace")
+	http.Warning("http warning")
+	http.Error("http error")
+	router.Warning("router warning")
+
+	expected := " [INFO] root info\n" +
+		"db [DEBUG] db debug\n" +
+		"db.pool [DEBUG] pool debug\n" +
+		"http [ERROR] http error\n" +
+		"http.router [WARNING] router warning\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	logger.ResetNamedLevel("http.router")
+	router.Warning("router warning")
+	if buf.Len() != 0 {
+		t.Errorf("Expected router to inherit the ERROR level of http, got %q", buf.String())
+	}
+}
+
+func TestNamedWithFields(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%logger% [%level%] %message%", TRACE)
+
+	child := logger.With("request_id", "abc").Named("db")
+	if child.Name() != "db" || len(child.fields) != 1 {
+		t.Fatalf("Expected named child with fields, got name %q and %d fields", child.Name(), len(child.fields))
+	}
+
+	logger.sinks[0].SetFormatter(NewJSONFormatter())
+	child.Info("Query")
+
+	if !strings.Contains(buf.String(), `"logger":"db","request_id":"abc"`) {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
@@ -0,0 +1,77 @@
+package gogger
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const networkDialTimeout = 5 * time.Second
+
+// netConn is the connection of a sink to a log server, dialed again once when a write fails.
+// It is not safe for concurrent use, the sink guards it with its mutex
+type netConn struct {
+	network string
+	address string
+	// server names the server in errors, such as "syslog"
+	server string
+	conn   net.Conn
+}
+
+// dial connects to the server
+func (c *netConn) dial() error {
+	conn, err := net.DialTimeout(c.network, c.address, networkDialTimeout)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %v", c.server, err)
+	}
+	c.conn = conn
+	return nil
+}
+
+// write sends the packets in order. When the connection fails the server is dialed
+// again and the packets are sent once more, a second failure drops the connection
+func (c *netConn) write(packets [][]byte) error {
+	if c.conn != nil {
+		if err := c.send(packets); err == nil {
+			return nil
+		}
+		_ = c.close()
+	}
+
+	if err := c.dial(); err != nil {
+		return err
+	}
+	if err := c.send(packets); err != nil {
+		_ = c.close()
+		return fmt.Errorf("error writing to %s: %v", c.server, err)
+	}
+	return nil
+}
+
+func (c *netConn) send(packets [][]byte) error {
+	for _, packet := range packets {
+		if _, err := c.conn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the connection, the next write dials the server again
+func (c *netConn) close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// isDatagram reports whether the network sends one message per packet
+func (c *netConn) isDatagram() bool {
+	switch c.network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return true
+	}
+	return false
+}
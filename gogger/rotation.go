@@ -0,0 +1,106 @@
+package gogger
+
+import (
+	"fmt"
+	"time"
+)
+
+type rotationKind int
+
+const (
+	rotateNone rotationKind = iota
+	rotateEvery
+	rotateDaily
+	rotateWeekly
+)
+
+// RotationPolicy describes time-based rotation of log files.
+// The zero value disables time-based rotation
+type RotationPolicy struct {
+	kind     rotationKind
+	interval time.Duration
+	hour     int
+	minute   int
+	weekday  time.Weekday
+	layout   string
+}
+
+// RotateEvery rotates files every interval, aligned to local midnight for intervals up to a day
+func RotateEvery(interval time.Duration) (RotationPolicy, error) {
+	if interval < time.Minute {
+		return RotationPolicy{}, fmt.Errorf("invalid rotation interval, the minimum is one minute")
+	}
+	return RotationPolicy{kind: rotateEvery, interval: interval, layout: "2006-01-02T15-04"}, nil
+}
+
+// RotateHourly rotates files at the beginning of every hour
+func RotateHourly() RotationPolicy {
+	return RotationPolicy{kind: rotateEvery, interval: time.Hour, layout: "2006-01-02T15"}
+}
+
+// RotateDaily rotates files every day at the given local time
+func RotateDaily(hour, minute int) (RotationPolicy, error) {
+	if err := validateTimeOfDay(hour, minute); err != nil {
+		return RotationPolicy{}, err
+	}
+	return RotationPolicy{kind: rotateDaily, hour: hour, minute: minute, layout: "2006-01-02"}, nil
+}
+
+// RotateWeekly rotates files every week on the given weekday and local time
+func RotateWeekly(weekday time.Weekday, hour, minute int) (RotationPolicy, error) {
+	if err := validateTimeOfDay(hour, minute); err != nil {
+		return RotationPolicy{}, err
+	}
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return RotationPolicy{}, fmt.Errorf("invalid weekday")
+	}
+	return RotationPolicy{kind: rotateWeekly, hour: hour, minute: minute, weekday: weekday, layout: "2006-01-02"}, nil
+}
+
+func validateTimeOfDay(hour, minute int) error {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return fmt.Errorf("invalid time of day")
+	}
+	return nil
+}
+
+// rotationTime returns the rotation time of day on the day days away from t
+func (p RotationPolicy) rotationTime(t time.Time, days int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+days, p.hour, p.minute, 0, 0, t.Location())
+}
+
+// periodStart returns the beginning of the rotation period containing t
+func (p RotationPolicy) periodStart(t time.Time) time.Time {
+	switch p.kind {
+	case rotateEvery:
+		if p.interval > 24*time.Hour {
+			return t.Truncate(p.interval)
+		}
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return midnight.Add(t.Sub(midnight).Truncate(p.interval))
+	case rotateDaily:
+		start := p.rotationTime(t, 0)
+		if t.Before(start) {
+			start = p.rotationTime(t, -1)
+		}
+		return start
+	case rotateWeekly:
+		days := (int(t.Weekday()) - int(p.weekday) + 7) % 7
+		start := p.rotationTime(t, -days)
+		if t.Before(start) {
+			start = p.rotationTime(t, -days-7)
+		}
+		return start
+	default:
+		return time.Time{}
+	}
+}
+
+// periodName returns the name of the rotation period containing t used in file names,
+// or an empty string when time-based rotation is disabled
+func (p RotationPolicy) periodName(t time.Time) string {
+	if p.kind == rotateNone {
+		return ""
+	}
+	return p.periodStart(t).Format(p.layout)
+}
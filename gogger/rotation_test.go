@@ -0,0 +1,61 @@
+package gogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotationPolicyPeriodName(t *testing.T) {
+	every15, err := RotateEvery(15 * time.Minute)
+	if err != nil {
+		t.Fatalf("RotateEvery returned unexpected error: %v", err)
+	}
+	daily, err := RotateDaily(6, 30)
+	if err != nil {
+		t.Fatalf("RotateDaily returned unexpected error: %v", err)
+	}
+	weekly, err := RotateWeekly(time.Monday, 0, 0)
+	if err != nil {
+		t.Fatalf("RotateWeekly returned unexpected error: %v", err)
+	}
+
+	// 2020-09-30 is a Wednesday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, 9, day, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name   string
+		policy RotationPolicy
+		time   time.Time
+		want   string
+	}{
+		{"Disabled", RotationPolicy{}, at(30, 21, 59), ""},
+		{"Every 15 minutes", every15, at(30, 21, 59), "2020-09-30T21-45"},
+		{"Hourly", RotateHourly(), at(30, 21, 59), "2020-09-30T21"},
+		{"Daily after rotation time", daily, at(30, 6, 30), "2020-09-30"},
+		{"Daily before rotation time", daily, at(30, 6, 29), "2020-09-29"},
+		{"Weekly", weekly, at(30, 21, 59), "2020-09-28"},
+		{"Weekly on rotation day", weekly, at(28, 0, 0), "2020-09-28"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.periodName(tt.time); got != tt.want {
+				t.Errorf("periodName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationPolicyValidation(t *testing.T) {
+	if _, err := RotateEvery(time.Second); err == nil {
+		t.Error("RotateEvery should return an error for intervals below a minute")
+	}
+	if _, err := RotateDaily(24, 0); err == nil {
+		t.Error("RotateDaily should return an error for an invalid hour")
+	}
+	if _, err := RotateWeekly(time.Weekday(7), 0, 0); err == nil {
+		t.Error("RotateWeekly should return an error for an invalid weekday")
+	}
+}
@@ -0,0 +1,149 @@
+package gogger
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink is a log output with its own level and formatter
+type Sink interface {
+	// Write outputs a record that passed the level check
+	Write(record *Record) error
+	// Flush commits buffered records to the underlying output
+	Flush() error
+	// Close flushes and releases the output
+	Close() error
+	// Level returns the minimum level of records accepted by the sink
+	Level() LogLevel
+	// SetLevel sets the minimum level of records accepted by the sink
+	SetLevel(level LogLevel)
+	// SetFormatter sets the formatter used to render records
+	SetFormatter(formatter Formatter)
+}
+
+// sinkBase holds the level and formatter shared by the built-in sinks.
+// mu also guards the state of the sink embedding it
+type sinkBase struct {
+	mu        sync.Mutex
+	level     LogLevel
+	formatter Formatter
+}
+
+// Level returns the minimum level of records accepted by the sink
+func (s *sinkBase) Level() LogLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.level
+}
+
+// SetLevel sets the minimum level of records accepted by the sink
+func (s *sinkBase) SetLevel(level LogLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.level = level
+}
+
+// SetFormatter sets the formatter used to render records
+func (s *sinkBase) SetFormatter(formatter Formatter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.formatter = formatter
+}
+
+// NeedsCaller reports whether the formatter renders the caller of records
+func (s *sinkBase) NeedsCaller() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.formatter.(CallerRenderer)
+	return ok && f.NeedsCaller()
+}
+
+// format renders the record, the caller must hold s.mu
+func (s *sinkBase) format(record *Record) string {
+	if s.formatter == nil {
+		s.formatter = newDefaultTextFormatter()
+	}
+	return s.formatter.Format(record)
+}
+
+// WriterSink writes records line by line to an io.Writer such as os.Stdout or os.Stderr.
+// It is safe for concurrent use, lines of concurrent records never interleave
+type WriterSink struct {
+	sinkBase
+	out    io.Writer
+	colors bool
+}
+
+// NewWriterSink creates a sink writing to out. The writer is not closed by the sink
+func NewWriterSink(out io.Writer) *WriterSink {
+	return &WriterSink{
+		sinkBase: sinkBase{
+			level:     DEBUG,
+			formatter: newDefaultTextFormatter(),
+		},
+		out: out,
+	}
+}
+
+// NewConsoleSink creates a sink writing to the standard output.
+// Colors are enabled when the standard output is a terminal and NO_COLOR is not set
+func NewConsoleSink() *WriterSink {
+	s := NewWriterSink(os.Stdout)
+	s.colors = supportsColors(os.Stdout)
+	return s
+}
+
+// Write outputs the formatted record followed by a newline
+func (s *WriterSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var formattedMessage string
+	if formatter, ok := s.formatter.(colorFormatter); ok && s.colors {
+		formattedMessage = formatter.formatColored(record)
+	} else {
+		formattedMessage = s.format(record)
+	}
+
+	_, err := io.WriteString(s.out, formattedMessage+"\n")
+	return err
+}
+
+// SetColors enables rendering of level names in the colors of their levels
+func (s *WriterSink) SetColors(colors bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.colors = colors
+}
+
+// supportsColors reports whether ANSI colors should be written to the file:
+// it must be a terminal, NO_COLOR must be unset or empty and TERM must not be "dumb"
+func supportsColors(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Flush flushes the writer if it supports flushing
+func (s *WriterSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if flusher, ok := s.out.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Close flushes the writer
+func (s *WriterSink) Close() error {
+	return s.Flush()
+}
@@ -0,0 +1,186 @@
+package gogger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestLogger creates a logger writing only to the sink
+func newTestLogger(t *testing.T, sink Sink) *Gogger {
+	logger, err := NewGogger("test.log", t.TempDir(), 100, 5)
+	if err != nil {
+		t.Fatalf("Failed to create Gogger instance: %v", err)
+	}
+	logger.SetUseConsoleLog(false)
+	logger.SetUseFileLog(false)
+	logger.AddSink(sink)
+	return logger
+}
+
+// newBufferTestSink creates a sink writing records of the level and above to a buffer in the format
+func newBufferTestSink(t *testing.T, format string, level LogLevel) (*WriterSink, *bytes.Buffer) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	sink.SetLevel(level)
+	formatter, err := NewTextFormatter(format)
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	sink.SetFormatter(formatter)
+	return sink, &buf
+}
+
+// newBufferTestLogger creates a logger writing only to a sink made by newBufferTestSink
+func newBufferTestLogger(t *testing.T, format string, level LogLevel) (*Gogger, *bytes.Buffer) {
+	sink, buf := newBufferTestSink(t, format, level)
+	logger := newTestLogger(t, sink)
+	t.Cleanup(logger.Close)
+	return logger, buf
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+
+	if sink.Level() != DEBUG {
+		t.Errorf("Expected default level DEBUG, got %v", sink.Level())
+	}
+
+	formatter, err := NewTextFormatter("%level%: %message%")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+	sink.SetFormatter(formatter)
+
+	if err := sink.Write(&Record{Level: INFO, Message: "hello"}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	if buf.String() != "INFO: hello\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestAddSink(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	defer Logger.Close()
+	Logger.SetUseConsoleLog(false)
+
+	var errorsBuf, allBuf bytes.Buffer
+	errorsSink := NewWriterSink(&errorsBuf)
+	errorsSink.SetLevel(ERROR)
+	allSink := NewWriterSink(&allBuf)
+	allSink.SetFormatter(NewJSONFormatter())
+
+	Logger.AddSink(errorsSink)
+	Logger.AddSink(allSink)
+
+	Logger.Debug("Debug message")
+	Logger.Error("Error message")
+
+	if strings.Contains(errorsBuf.String(), "Debug message") || !strings.Contains(errorsBuf.String(), "Error message") {
+		t.Errorf("Unexpected output of the error sink: %q", errorsBuf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(allBuf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "{") {
+		t.Errorf("Expected 2 JSON lines, got %q", allBuf.String())
+	}
+
+	Logger.RemoveSink(allSink)
+	Logger.Error("After removal")
+
+	if strings.Contains(allBuf.String(), "After removal") {
+		t.Error("Removed sink still receives records")
+	}
+	if !strings.Contains(errorsBuf.String(), "After removal") {
+		t.Error("Remaining sink does not receive records")
+	}
+}
+
+func TestNoActiveSinks(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	defer Logger.Close()
+
+	Logger.SetUseConsoleLog(false)
+	Logger.SetUseFileLog(false)
+
+	if len(Logger.activeSinks()) != 0 {
+		t.Errorf("Expected no active sinks, got %d", len(Logger.activeSinks()))
+	}
+
+	var buf bytes.Buffer
+	Logger.AddSink(NewWriterSink(&buf))
+	Logger.Info("Only added sink")
+
+	if !strings.Contains(buf.String(), "Only added sink") {
+		t.Error("Added sink does not receive records when built-in sinks are disabled")
+	}
+}
+
+func TestSupportsColors(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	terminal, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	defer terminal.Close()
+
+	tests := []struct {
+		name     string
+		file     *os.File
+		noColor  string
+		term     string
+		expected bool
+	}{
+		{"Character device", terminal, "", "xterm", true},
+		{"Pipe", writer, "", "xterm", false},
+		{"NO_COLOR", terminal, "1", "xterm", false},
+		{"Dumb terminal", terminal, "", "dumb", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("TERM", tt.term)
+
+			if got := supportsColors(tt.file); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConsoleColorsNotInFile(t *testing.T) {
+	tempDir := t.TempDir()
+	InitGogger("test.log", tempDir, 100, 5)
+	defer Logger.Close()
+
+	var buf bytes.Buffer
+	Logger.consoleSink.out = &buf
+	Logger.SetUseConsoleColors(true)
+	Logger.Warning("Colored message")
+
+	if !strings.Contains(buf.String(), "\x1b[33mWARNING\x1b[0m") {
+		t.Errorf("Expected colored console output, got %q", buf.String())
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "#0test.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if strings.Contains(string(content), "\x1b[") {
+		t.Errorf("File output contains color codes: %q", content)
+	}
+}
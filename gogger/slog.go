@@ -0,0 +1,187 @@
+package gogger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that writes records through a Gogger
+type SlogHandler struct {
+	logger *Gogger
+	fields []Field
+	prefix string
+}
+
+// NewSlogHandler creates a slog.Handler backed by the logger, use it with slog.New
+func NewSlogHandler(l *Gogger) *SlogHandler {
+	return &SlogHandler{logger: l}
+}
+
+// Enabled reports whether the logger accepts records of the level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(levelFromSlog(level))
+}
+
+// Handle writes the record with its attributes as fields, timestamped by the logger clock
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	level := levelFromSlog(r.Level)
+	sinks := h.logger.activeSinks()
+	if len(sinks) == 0 || !h.logger.namedEnabled(level) {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(h.logger.fields)+len(h.fields)+r.NumAttrs())
+	fields = append(fields, h.logger.fields...)
+	fields = append(fields, h.fields...)
+	r.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	// The logger clock is used instead of r.Time so SetClock applies to slog records too
+	record := &Record{
+		Time:    h.logger.now(),
+		Level:   level,
+		Message: r.Message,
+		Fields:  fields,
+		Logger:  h.logger.name,
+	}
+	record.timestampLayout, record.location = h.logger.timestampSettings()
+	if r.PC != 0 && needsCaller(sinks) {
+		record.Caller = callerFromPC(r.PC)
+	}
+
+	h.logger.output(record, sinks)
+
+	return nil
+}
+
+// WithAttrs returns a handler adding the attributes to every record
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := append([]Field(nil), h.fields...)
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+	}
+	return &SlogHandler{logger: h.logger, fields: fields, prefix: h.prefix}
+}
+
+// WithGroup returns a handler qualifying the keys of the following attributes with the group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{logger: h.logger, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr converts the attribute to fields, flattening groups into dotted keys
+func appendSlogAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, groupAttr)
+		}
+		return fields
+	}
+
+	return append(fields, Field{Key: prefix + attr.Key, Value: attr.Value.Any()})
+}
+
+// levelFromSlog maps a slog level to the closest LogLevel
+func levelFromSlog(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelDebug:
+		return TRACE
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelInfo+2:
+		return INFO
+	case level < slog.LevelWarn:
+		return NOTICE
+	case level < slog.LevelError:
+		return WARNING
+	case level < slog.LevelError+4:
+		return ERROR
+	default:
+		return CRITICAL
+	}
+}
+
+// levelToSlog maps a LogLevel, including custom ones, to the closest slog level
+func levelToSlog(level LogLevel) slog.Level {
+	switch {
+	case level < DEBUG:
+		return slog.LevelDebug - 4
+	case level < INFO:
+		return slog.LevelDebug
+	case level < NOTICE:
+		return slog.LevelInfo
+	case level < WARNING:
+		return slog.LevelInfo + 2
+	case level < ERROR:
+		return slog.LevelWarn
+	case level < CRITICAL:
+		return slog.LevelError
+	default:
+		return slog.LevelError + 4
+	}
+}
+
+// SlogSink is a Sink that emits records into a slog.Handler
+type SlogSink struct {
+	sinkBase
+	handler slog.Handler
+}
+
+// NewSlogSink creates a sink writing to the handler. Records carry the plain message
+// unless a formatter is set, fields and the logger name become attributes
+func NewSlogSink(handler slog.Handler) *SlogSink {
+	return &SlogSink{
+		sinkBase: sinkBase{level: TRACE},
+		handler:  handler,
+	}
+}
+
+// Write passes the record to the handler if it is enabled for the level
+func (s *SlogSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	level := levelToSlog(record.Level)
+	if !s.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	message := record.Message
+	if s.formatter != nil {
+		message = s.format(record)
+	}
+
+	r := slog.NewRecord(record.Time, level, message, 0)
+	if record.Logger != "" {
+		r.AddAttrs(slog.String("logger", record.Logger))
+	}
+	for _, field := range record.Fields {
+		r.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+
+	return s.handler.Handle(ctx, r)
+}
+
+// Flush does nothing, slog handlers write synchronously
+func (s *SlogSink) Flush() error {
+	return nil
+}
+
+// Close does nothing, the handler is owned by the caller
+func (s *SlogSink) Close() error {
+	return nil
+}
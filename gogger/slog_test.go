@@ -0,0 +1,101 @@
+package gogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "[%level%] %message% %fields%", INFO)
+
+	slogger := slog.New(NewSlogHandler(logger.With("service", "api")))
+	slogger.Debug("Filtered")
+	slogger.Info("Started", "port", 8080)
+	slogger.With("request", "abc").WithGroup("http").Warn("Slow", "status", 200, slog.Group("timing", "total", time.Second))
+	slogger.Error("Failed", slog.Group("", "inline", true), slog.Group("empty"))
+
+	expected := "[INFO] Started service=api port=8080\n" +
+		"[WARNING] Slow service=api request=abc http.status=200 http.timing.total=1s\n" +
+		"[ERROR] Failed service=api inline=true\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestSlogLevelMapping(t *testing.T) {
+	tests := []struct {
+		slogLevel slog.Level
+		level     LogLevel
+	}{
+		{slog.LevelDebug - 4, TRACE},
+		{slog.LevelDebug, DEBUG},
+		{slog.LevelInfo, INFO},
+		{slog.LevelInfo + 2, NOTICE},
+		{slog.LevelWarn, WARNING},
+		{slog.LevelError, ERROR},
+		{slog.LevelError + 4, CRITICAL},
+	}
+
+	for _, tt := range tests {
+		if got := levelFromSlog(tt.slogLevel); got != tt.level {
+			t.Errorf("levelFromSlog(%v) = %v, want %v", tt.slogLevel, got, tt.level)
+		}
+		if got := levelToSlog(tt.level); got != tt.slogLevel {
+			t.Errorf("levelToSlog(%v) = %v, want %v", tt.level, got, tt.slogLevel)
+		}
+	}
+
+	if got := levelToSlog(FATAL); got != slog.LevelError+4 {
+		t.Errorf("levelToSlog(FATAL) = %v, want %v", got, slog.LevelError+4)
+	}
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	logger := newTestLogger(t, NewSlogSink(handler))
+	defer logger.Close()
+
+	named := logger.GetLogger("db").With("table", "users")
+	named.Debug("Filtered by the handler")
+	named.ErrorW("Query failed", "rows", 0)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 record, got %d: %q", len(lines), buf.String())
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Fatalf("Failed to decode slog output: %v", err)
+	}
+
+	expected := map[string]any{
+		"level":  "ERROR",
+		"msg":    "Query failed",
+		"logger": "db",
+		"table":  "users",
+		"rows":   float64(0),
+	}
+	for key, want := range expected {
+		if decoded[key] != want {
+			t.Errorf("Expected %s = %v, got %v", key, want, decoded[key])
+		}
+	}
+}
+
+func TestSlogHandlerUsesClock(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%timestamp% %message%", INFO)
+	logger.SetClock(NewFakeClock(time.Date(2020, 9, 30, 21, 59, 5, 0, time.Local)))
+
+	slog.New(NewSlogHandler(logger)).Info("Started")
+
+	if expected := "30-09-2020 21:59:05 Started\n"; buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,334 @@
+package gogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyslogFormat is the message format of a SyslogSink
+type SyslogFormat int
+
+const (
+	// RFC5424 is the structured syslog format with fields sent as structured data
+	RFC5424 SyslogFormat = iota
+	// RFC3164 is the legacy BSD syslog format with fields appended to the message
+	RFC3164
+)
+
+// Facility is the syslog facility of the records
+type Facility int
+
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	FacilityLocal0 Facility = iota + 4
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// Syslog severities, from the most to the least severe
+const (
+	severityEmergency = iota
+	severityAlert
+	severityCritical
+	severityError
+	severityWarning
+	severityNotice
+	severityInfo
+	severityDebug
+)
+
+// defaultStructuredDataID uses the enterprise number reserved for documentation by RFC 5612
+const defaultStructuredDataID = "fields@32473"
+
+// Formatters of the message part used until another formatter is set.
+// RFC 3164 has no structured data, so the fields follow the message
+var (
+	rfc5424MessageFormatter = newSyslogMessageFormatter("%message%")
+	rfc3164MessageFormatter = newSyslogMessageFormatter("%message%%[ %fields%%]")
+)
+
+// SyslogSink sends records to a syslog server over "udp", "tcp", "unix" or "unixgram".
+// A failed connection is dialed again on the next write. It is safe for concurrent use
+type SyslogSink struct {
+	sinkBase
+	conn             netConn
+	syslogFormat     SyslogFormat
+	facility         Facility
+	hostname         string
+	appName          string
+	structuredDataID string
+}
+
+// NewSyslogSink creates a sink sending records to the syslog server at the address, such as
+// NewSyslogSink("udp", "localhost:514") or NewSyslogSink("unixgram", "/dev/log")
+func NewSyslogSink(network, address string) (*SyslogSink, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("invalid syslog network %q", network)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	s := &SyslogSink{
+		sinkBase:         sinkBase{level: DEBUG, formatter: rfc5424MessageFormatter},
+		conn:             netConn{network: network, address: address, server: "syslog"},
+		facility:         FacilityUser,
+		hostname:         hostname,
+		appName:          syslogName(filepath.Base(os.Args[0]), 48),
+		structuredDataID: defaultStructuredDataID,
+	}
+
+	if err := s.conn.dial(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Write renders the record in the syslog format and sends it framed for the transport
+func (s *SyslogSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn.write([][]byte{s.frame(s.formatMessage(record))})
+}
+
+// Flush does nothing, records are sent as they are written
+func (s *SyslogSink) Flush() error {
+	return nil
+}
+
+// Close closes the connection to the syslog server
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.close()
+}
+
+// SetSyslogFormat sets the message format, RFC5424 by default
+func (s *SyslogSink) SetSyslogFormat(format SyslogFormat) error {
+	if format != RFC5424 && format != RFC3164 {
+		return fmt.Errorf("invalid syslog format")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.formatter == syslogMessageFormatter(s.syslogFormat) {
+		s.formatter = syslogMessageFormatter(format)
+	}
+	s.syslogFormat = format
+	return nil
+}
+
+// SetFacility sets the facility of the records, FacilityUser by default
+func (s *SyslogSink) SetFacility(facility Facility) error {
+	if facility < FacilityKern || facility > FacilityLocal7 {
+		return fmt.Errorf("invalid syslog facility %d", int(facility))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.facility = facility
+	return nil
+}
+
+// SetHostname sets the host name sent with the records, the name of the machine by default
+func (s *SyslogSink) SetHostname(hostname string) error {
+	if !isSyslogName(hostname, 255) {
+		return fmt.Errorf("invalid syslog hostname %q", hostname)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hostname = hostname
+	return nil
+}
+
+// SetAppName sets the application name sent with the records, the name of the executable by default
+func (s *SyslogSink) SetAppName(appName string) error {
+	if !isSyslogName(appName, 48) {
+		return fmt.Errorf("invalid syslog app-name %q", appName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appName = appName
+	return nil
+}
+
+// SetStructuredDataID sets the RFC 5424 structured data ID holding the fields, "fields@32473" by default
+func (s *SyslogSink) SetStructuredDataID(id string) error {
+	if !isSyslogName(id, 32) || strings.ContainsAny(id, "=]\"") {
+		return fmt.Errorf("invalid syslog structured data ID %q", id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.structuredDataID = id
+	return nil
+}
+
+// formatMessage renders the record in the syslog format, the caller must hold s.mu
+func (s *SyslogSink) formatMessage(record *Record) string {
+	priority := int(s.facility)*8 + syslogSeverity(record.Level)
+
+	message := s.format(record)
+
+	var sb strings.Builder
+	if s.syslogFormat == RFC3164 {
+		fmt.Fprintf(&sb, "<%d>%s %s %s[%d]: %s",
+			priority, record.Time.Format(time.Stamp), s.hostname, s.appName, os.Getpid(), message)
+		return sb.String()
+	}
+
+	msgID := "-"
+	if record.Logger != "" {
+		msgID = syslogName(record.Logger, 32)
+	}
+	fmt.Fprintf(&sb, "<%d>1 %s %s %s %d %s ",
+		priority, record.Time.Format("2006-01-02T15:04:05.000000Z07:00"), s.hostname, s.appName, os.Getpid(), msgID)
+	s.writeStructuredData(&sb, record.Fields)
+	if message != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(message)
+	}
+	return sb.String()
+}
+
+// writeStructuredData renders the fields as a single structured data element, the caller must hold s.mu
+func (s *SyslogSink) writeStructuredData(sb *strings.Builder, fields []Field) {
+	if len(fields) == 0 {
+		sb.WriteByte('-')
+		return
+	}
+
+	sb.WriteByte('[')
+	sb.WriteString(s.structuredDataID)
+	for _, field := range fields {
+		sb.WriteByte(' ')
+		sb.WriteString(syslogParamName(field.Key))
+		sb.WriteString(`="`)
+		sb.WriteString(escapeSyslogParamValue(fmt.Sprint(field.Value)))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte(']')
+}
+
+// frame prepares the message for the transport: one message per datagram,
+// octet counting for RFC 5424 and a trailing newline for RFC 3164 over streams
+func (s *SyslogSink) frame(message string) []byte {
+	if s.conn.isDatagram() {
+		return []byte(message)
+	}
+	if s.syslogFormat == RFC3164 {
+		return []byte(message + "\n")
+	}
+	return []byte(strconv.Itoa(len(message)) + " " + message)
+}
+
+// syslogMessageFormatter returns the default formatter of the message part in the format
+func syslogMessageFormatter(format SyslogFormat) Formatter {
+	if format == RFC3164 {
+		return rfc3164MessageFormatter
+	}
+	return rfc5424MessageFormatter
+}
+
+func newSyslogMessageFormatter(format string) *TextFormatter {
+	formatter, err := NewTextFormatter(format)
+	if err != nil {
+		panic(err)
+	}
+	return formatter
+}
+
+// syslogSeverity maps a level to a syslog severity, custom levels use the severity of the built-in level below them
+func syslogSeverity(level LogLevel) int {
+	switch {
+	case level >= PANIC:
+		return severityEmergency
+	case level >= FATAL:
+		return severityAlert
+	case level >= CRITICAL:
+		return severityCritical
+	case level >= ERROR:
+		return severityError
+	case level >= WARNING:
+		return severityWarning
+	case level >= NOTICE:
+		return severityNotice
+	case level >= INFO:
+		return severityInfo
+	default:
+		return severityDebug
+	}
+}
+
+// isSyslogName reports whether name is 1 to maxLength printable ASCII characters without spaces
+func isSyslogName(name string, maxLength int) bool {
+	if name == "" || len(name) > maxLength {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] <= ' ' || name[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// syslogName replaces the characters not allowed in syslog header fields and truncates the name
+func syslogName(name string, maxLength int) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+// syslogParamName makes the field key a valid structured data parameter name
+func syslogParamName(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+	return syslogName(key, 32)
+}
+
+func escapeSyslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,252 @@
+package gogger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSyslogSink(t *testing.T, network, address string) *SyslogSink {
+	sink, err := NewSyslogSink(network, address)
+	if err != nil {
+		t.Fatalf("NewSyslogSink returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = sink.Close() })
+
+	if err := sink.SetHostname("host"); err != nil {
+		t.Fatalf("SetHostname returned unexpected error: %v", err)
+	}
+	if err := sink.SetAppName("app"); err != nil {
+		t.Fatalf("SetAppName returned unexpected error: %v", err)
+	}
+	return sink
+}
+
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read syslog message: %v", err)
+	}
+	return string(buf[:n])
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink := newTestSyslogSink(t, "udp", listener.LocalAddr().String())
+	if err := sink.SetFacility(FacilityLocal3); err != nil {
+		t.Fatalf("SetFacility returned unexpected error: %v", err)
+	}
+
+	record := &Record{
+		Time:    time.Date(2020, 9, 30, 21, 59, 5, 123456000, time.UTC),
+		Level:   ERROR,
+		Message: "request failed",
+		Logger:  "http",
+		Fields:  []Field{F("user id", 42), F("path", `/a"b]`)},
+	}
+	if err := sink.Write(record); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	// local3 (19) * 8 + err (3) = 155
+	expected := fmt.Sprintf(`<155>1 2020-09-30T21:59:05.123456Z host app %d http [fields@32473 user_id="42" path="/a\"b\]"] request failed`, os.Getpid())
+	if got := readPacket(t, listener); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if err := sink.Write(&Record{Time: record.Time, Level: INFO, Message: "plain"}); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+	expected = fmt.Sprintf(`<158>1 2020-09-30T21:59:05.123456Z host app %d - - plain`, os.Getpid())
+	if got := readPacket(t, listener); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+
+	sink := newTestSyslogSink(t, "tcp", listener.Addr().String())
+	if err := sink.SetSyslogFormat(RFC3164); err != nil {
+		t.Fatalf("SetSyslogFormat returned unexpected error: %v", err)
+	}
+
+	record := &Record{
+		Time:    time.Date(2020, 9, 3, 21, 59, 5, 0, time.UTC),
+		Level:   WARNING,
+		Message: "disk low",
+		Fields:  []Field{F("free", "1GB")},
+	}
+	if err := sink.Write(record); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	// user (1) * 8 + warning (4) = 12
+	expected := fmt.Sprintf("<12>Sep  3 21:59:05 host app[%d]: disk low free=1GB", os.Getpid())
+	select {
+	case got := <-lines:
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for syslog message")
+	}
+}
+
+func TestSyslogSinkUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syslog.sock")
+	listener, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("Unix datagram sockets are not available: %v", err)
+	}
+	defer listener.Close()
+
+	sink := newTestSyslogSink(t, "unixgram", path)
+	logger := newTestLogger(t, sink)
+	defer logger.Close()
+
+	logger.Named("db").Notice("connected")
+
+	if got := readPacket(t, listener); !strings.HasPrefix(got, "<13>1 ") || !strings.HasSuffix(got, " host app "+fmt.Sprint(os.Getpid())+" db - connected") {
+		t.Errorf("Unexpected syslog message %q", got)
+	}
+}
+
+func TestSyslogSinkReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink := newTestSyslogSink(t, "tcp", listener.Addr().String())
+
+	// Drop the first connection
+	first, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept: %v", err)
+	}
+	first.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var length int
+		if _, err := fmt.Fscanf(reader, "%d ", &length); err != nil {
+			return
+		}
+		message := make([]byte, length)
+		if _, err := io.ReadFull(reader, message); err == nil {
+			received <- string(message)
+		}
+	}()
+
+	// Writes to the closed connection fail after the peer resets it, then the sink dials again
+	deadline := time.After(5 * time.Second)
+	for {
+		_ = sink.Write(&Record{Time: time.Now(), Level: INFO, Message: "after reconnect"})
+
+		select {
+		case got := <-received:
+			if !strings.HasSuffix(got, " after reconnect") {
+				t.Errorf("Unexpected syslog message %q", got)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Sink did not reconnect")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		level    LogLevel
+		expected int
+	}{
+		{TRACE, 7},
+		{DEBUG, 7},
+		{INFO, 6},
+		{NOTICE, 5},
+		{WARNING, 4},
+		{ERROR, 3},
+		{ERROR + 5, 3},
+		{CRITICAL, 2},
+		{FATAL, 1},
+		{PANIC, 0},
+	}
+
+	for _, tt := range tests {
+		if got := syslogSeverity(tt.level); got != tt.expected {
+			t.Errorf("syslogSeverity(%v) = %d, expected %d", tt.level, got, tt.expected)
+		}
+	}
+}
+
+func TestSyslogSinkValidation(t *testing.T) {
+	if _, err := NewSyslogSink("http", "localhost:514"); err == nil {
+		t.Error("NewSyslogSink should return an error for an unsupported network")
+	}
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	sink := newTestSyslogSink(t, "udp", listener.LocalAddr().String())
+
+	if err := sink.SetFacility(Facility(24)); err == nil {
+		t.Error("SetFacility should return an error for an unknown facility")
+	}
+	if err := sink.SetHostname("my host"); err == nil {
+		t.Error("SetHostname should return an error for a name with spaces")
+	}
+	if err := sink.SetAppName(strings.Repeat("a", 49)); err == nil {
+		t.Error("SetAppName should return an error for a name longer than 48 characters")
+	}
+	if err := sink.SetStructuredDataID("bad=id"); err == nil {
+		t.Error("SetStructuredDataID should return an error for an ID with =")
+	}
+	if err := sink.SetSyslogFormat(SyslogFormat(5)); err == nil {
+		t.Error("SetSyslogFormat should return an error for an unknown format")
+	}
+}
@@ -0,0 +1,347 @@
+package gogger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// placeholderKind identifies what a template node renders
+type placeholderKind int
+
+const (
+	nodeText placeholderKind = iota
+	nodeSection
+	nodeTimestamp
+	nodeLevel
+	nodeMessage
+	nodeFields
+	nodeLogger
+	nodeFile
+	nodeLine
+	nodeFunc
+	nodeField
+)
+
+// maxPlaceholderWidth limits the width and precision of a placeholder
+const maxPlaceholderWidth = 1024
+
+var placeholderKinds = map[string]placeholderKind{
+	"timestamp": nodeTimestamp,
+	"level":     nodeLevel,
+	"message":   nodeMessage,
+	"fields":    nodeFields,
+	"logger":    nodeLogger,
+	"file":      nodeFile,
+	"line":      nodeLine,
+	"func":      nodeFunc,
+	"field":     nodeField,
+}
+
+// letterCase is the case modifier of a placeholder
+type letterCase int
+
+const (
+	caseNone letterCase = iota
+	caseUpper
+	caseLower
+)
+
+// templateNode is a literal text, an optional section or a placeholder of a compiled format
+type templateNode struct {
+	kind       placeholderKind
+	text       string
+	arg        string
+	leftAlign  bool
+	width      int
+	precision  int
+	letterCase letterCase
+	section    []templateNode
+}
+
+// template is a format compiled once and rendered for every record.
+//
+// A placeholder is written as %[-][width][.precision]name[:argument][|upper|lower]%:
+// %-7level% pads the level to 7 characters aligned left, %.10message% keeps the first 10 characters,
+// %timestamp:15:04:05% uses a custom layout, %field:user% renders a single field and
+// %level|lower% changes the case. %[ ... %] is printed only when all placeholders inside are not empty
+// and %% is a literal percent sign. Text that is not a valid placeholder is kept as is
+type template struct {
+	nodes []templateNode
+}
+
+// parseTemplate compiles the format
+func parseTemplate(format string) (*template, error) {
+	p := &templateParser{format: format}
+	nodes, _ := p.parse(false)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &template{nodes: nodes}, nil
+}
+
+type templateParser struct {
+	format string
+	pos    int
+	err    error
+}
+
+// parse reads nodes until the end of the format or, inside a section, until %]. closed reports that %] was found
+func (p *templateParser) parse(inSection bool) (nodes []templateNode, closed bool) {
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			nodes = append(nodes, templateNode{kind: nodeText, text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for p.pos < len(p.format) && p.err == nil {
+		rest := p.format[p.pos:]
+
+		switch {
+		case rest[0] != '%':
+			end := strings.IndexByte(rest, '%')
+			if end < 0 {
+				end = len(rest)
+			}
+			literal.WriteString(rest[:end])
+			p.pos += end
+
+		case strings.HasPrefix(rest, "%%"):
+			literal.WriteByte('%')
+			p.pos += 2
+
+		case strings.HasPrefix(rest, "%["):
+			flush()
+			p.pos += 2
+			section, sectionClosed := p.parse(true)
+			if !sectionClosed && p.err == nil {
+				p.err = fmt.Errorf("invalid log format. Optional section is not closed with %%]")
+			}
+			nodes = append(nodes, templateNode{kind: nodeSection, section: section})
+
+		case strings.HasPrefix(rest, "%]") && inSection:
+			flush()
+			p.pos += 2
+			return nodes, true
+
+		default:
+			end := strings.IndexByte(rest[1:], '%')
+			if end >= 0 {
+				node, ok, err := parsePlaceholder(rest[1 : end+1])
+				if err != nil {
+					p.err = err
+					break
+				}
+				if ok {
+					flush()
+					nodes = append(nodes, node)
+					p.pos += end + 2
+					continue
+				}
+			}
+			literal.WriteByte('%')
+			p.pos++
+		}
+	}
+
+	flush()
+	return nodes, false
+}
+
+// parsePlaceholder parses the text between the percent signs, ok is false when it is not a placeholder.
+// A placeholder with a width or precision above maxPlaceholderWidth is an error
+func parsePlaceholder(spec string) (node templateNode, ok bool, err error) {
+	node.precision = -1
+	placeholder := spec
+
+	if strings.HasPrefix(spec, "-") {
+		node.leftAlign = true
+		spec = spec[1:]
+	}
+
+	widthDigits := leadingDigits(spec)
+	spec = spec[len(widthDigits):]
+
+	precisionDigits := ""
+	if strings.HasPrefix(spec, ".") {
+		precisionDigits = leadingDigits(spec[1:])
+		if precisionDigits == "" {
+			return node, false, nil
+		}
+		spec = spec[1+len(precisionDigits):]
+	}
+
+	nameEnd := 0
+	for nameEnd < len(spec) && spec[nameEnd] >= 'a' && spec[nameEnd] <= 'z' {
+		nameEnd++
+	}
+	kind, known := placeholderKinds[spec[:nameEnd]]
+	if !known {
+		return node, false, nil
+	}
+	node.kind = kind
+	spec = spec[nameEnd:]
+
+	if i := strings.LastIndexByte(spec, '|'); i >= 0 {
+		switch spec[i+1:] {
+		case "upper":
+			node.letterCase = caseUpper
+		case "lower":
+			node.letterCase = caseLower
+		default:
+			return node, false, nil
+		}
+		spec = spec[:i]
+	}
+
+	if spec != "" {
+		if spec[0] != ':' || len(spec) == 1 || (kind != nodeTimestamp && kind != nodeField) {
+			return node, false, nil
+		}
+		node.arg = spec[1:]
+	} else if kind == nodeField {
+		return node, false, nil
+	}
+
+	if widthDigits != "" {
+		if node.width, err = strconv.Atoi(widthDigits); err != nil || node.width > maxPlaceholderWidth {
+			return node, false, fmt.Errorf("invalid log format. The width of %%%s%% must be at most %d", placeholder, maxPlaceholderWidth)
+		}
+	}
+	if precisionDigits != "" {
+		if node.precision, err = strconv.Atoi(precisionDigits); err != nil || node.precision > maxPlaceholderWidth {
+			return node, false, fmt.Errorf("invalid log format. The precision of %%%s%% must be at most %d", placeholder, maxPlaceholderWidth)
+		}
+	}
+
+	return node, true, nil
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// has reports whether the template contains a placeholder of one of the kinds
+func (t *template) has(kinds ...placeholderKind) bool {
+	return nodesHave(t.nodes, kinds)
+}
+
+func nodesHave(nodes []templateNode, kinds []placeholderKind) bool {
+	for _, node := range nodes {
+		if node.kind == nodeSection && nodesHave(node.section, kinds) {
+			return true
+		}
+		for _, kind := range kinds {
+			if node.kind == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// render writes the record, the level name is wrapped in its color when colored is set
+func (t *template) render(record *Record, colored bool) string {
+	var sb strings.Builder
+	renderNodes(&sb, t.nodes, record, colored)
+	return sb.String()
+}
+
+// renderNodes writes the nodes and reports whether all of their placeholders were not empty
+func renderNodes(sb *strings.Builder, nodes []templateNode, record *Record, colored bool) bool {
+	complete := true
+
+	for _, node := range nodes {
+		switch node.kind {
+		case nodeText:
+			sb.WriteString(node.text)
+		case nodeSection:
+			var section strings.Builder
+			if renderNodes(&section, node.section, record, colored) {
+				sb.WriteString(section.String())
+			}
+		default:
+			value := placeholderValue(node, record)
+			if value == "" {
+				complete = false
+			}
+			writePlaceholder(sb, node, value, colored && node.kind == nodeLevel, record.Level)
+		}
+	}
+
+	return complete
+}
+
+func placeholderValue(node templateNode, record *Record) string {
+	switch node.kind {
+	case nodeTimestamp:
+		if node.arg != "" {
+			return formatTimestamp(record.Time, node.arg, record.location)
+		}
+		return record.Timestamp()
+	case nodeLevel:
+		return getLogLevelString(record.Level)
+	case nodeMessage:
+		return record.Message
+	case nodeFields:
+		return formatFields(record.Fields)
+	case nodeLogger:
+		return record.Logger
+	case nodeFile:
+		return record.Caller.ShortFile()
+	case nodeLine:
+		if record.Caller.Line == 0 {
+			return ""
+		}
+		return strconv.Itoa(record.Caller.Line)
+	case nodeFunc:
+		return record.Caller.ShortFunction()
+	case nodeField:
+		for i := len(record.Fields) - 1; i >= 0; i-- {
+			if record.Fields[i].Key == node.arg {
+				return fmt.Sprint(record.Fields[i].Value)
+			}
+		}
+	}
+	return ""
+}
+
+// writePlaceholder applies the case, truncation, color and padding of the node to the value
+func writePlaceholder(sb *strings.Builder, node templateNode, value string, colored bool, level LogLevel) {
+	switch node.letterCase {
+	case caseUpper:
+		value = strings.ToUpper(value)
+	case caseLower:
+		value = strings.ToLower(value)
+	}
+
+	if node.precision >= 0 && utf8.RuneCountInString(value) > node.precision {
+		value = string([]rune(value)[:node.precision])
+	}
+
+	padding := ""
+	if length := utf8.RuneCountInString(value); length < node.width {
+		padding = strings.Repeat(" ", node.width-length)
+	}
+
+	if colored {
+		if color := getLogLevelColor(level); color != "" {
+			value = "\x1b[" + color + "m" + value + "\x1b[0m"
+		}
+	}
+
+	if node.leftAlign {
+		sb.WriteString(value)
+		sb.WriteString(padding)
+	} else {
+		sb.WriteString(padding)
+		sb.WriteString(value)
+	}
+}
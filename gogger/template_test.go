@@ -0,0 +1,85 @@
+package gogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplateRender(t *testing.T) {
+	record := &Record{
+		Time:    time.Date(2020, 9, 30, 21, 59, 5, 0, time.UTC),
+		Level:   INFO,
+		Message: "disk %level% low",
+		Fields:  []Field{F("user", 42)},
+	}
+	named := &Record{Level: WARNING, Message: "started", Logger: "http"}
+
+	tests := []struct {
+		name     string
+		format   string
+		record   *Record
+		expected string
+	}{
+		{"Left aligned", "%-7level%|", record, "INFO   |"},
+		{"Right aligned", "%7level%|", record, "   INFO|"},
+		{"Truncation", "%.4message%", record, "disk"},
+		{"Truncation and width", "%-6.3level|lower%|", record, "inf   |"},
+		{"Upper case", "%logger|upper% %message%", named, "HTTP started"},
+		{"Message is not rewritten", "[%level%] %message%", record, "[INFO] disk %level% low"},
+		{"Escaped percent", "100%% %message%", named, "100% started"},
+		{"Inline timestamp layout", "%timestamp:15:04% %message%", record, "21:59 disk %level% low"},
+		{"Single field", "%message% user=%field:user%", record, "disk %level% low user=42"},
+		{"Section printed", "%[[%logger%] %]%message%", named, "[http] started"},
+		{"Section skipped", "%[[%logger%] %]%message%", record, "disk %level% low"},
+		{"Section with field", "%message%%[ user=%field:user%%]", named, "started"},
+		{"Lone percent", "50% of %message%", named, "50% of started"},
+		{"Unknown placeholder", "%unknown% %message%", named, "%unknown% started"},
+		{"Wide non-placeholder", "%2000 items% %message%", named, "%2000 items% started"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewTextFormatter(tt.format)
+			if err != nil {
+				t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+			}
+			if got := formatter.Format(tt.record); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTemplateColoredPadding(t *testing.T) {
+	formatter, err := NewTextFormatter("%-7level%|")
+	if err != nil {
+		t.Fatalf("NewTextFormatter returned unexpected error: %v", err)
+	}
+
+	got := formatter.formatColored(&Record{Level: INFO})
+	if got != "\x1b[32mINFO\x1b[0m   |" {
+		t.Errorf("Expected padding outside of the color codes, got %q", got)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+	}{
+		{"Unclosed section", "%[%logger% %message%"},
+		{"No required placeholder", "%file%:%line%"},
+		{"Escaped placeholder only", "%%message%%"},
+		{"Width overflow", "%99999999999999999999level% %message%"},
+		{"Width too large", "%1025level% %message%"},
+		{"Precision too large", "%.5000message%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTextFormatter(tt.format); err == nil {
+				t.Errorf("NewTextFormatter(%q) should return an error", tt.format)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package gogger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp layouts accepted by SetTimestampFormat and the %timestamp:LAYOUT% placeholder
+// in addition to any Go time layout
+const (
+	TimestampDefault     = "02-01-2006 15:04:05"
+	TimestampRFC3339     = "RFC3339"
+	TimestampRFC3339Nano = "RFC3339Nano"
+	TimestampUnix        = "unix"
+	TimestampUnixMilli   = "unixms"
+	TimestampUnixNano    = "unixns"
+)
+
+// SetTimestampFormat sets the layout of %timestamp% and of the JSON timestamp.
+// The layout is one of the Timestamp constants or a Go time layout such as "2006-01-02 15:04:05.000"
+func (l *Gogger) SetTimestampFormat(layout string) error {
+	if err := validateTimestampLayout(layout); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timestampLayout = layout
+	return nil
+}
+
+// SetTimezone sets the timezone of timestamps, "UTC", "Local" or an IANA name such as "Europe/Moscow"
+func (l *Gogger) SetTimezone(name string) error {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %v", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.location = location
+	return nil
+}
+
+// timestampSettings returns the timestamp layout and timezone of new records
+func (l *Gogger) timestampSettings() (string, *time.Location) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.timestampLayout, l.location
+}
+
+// Timestamp returns the record time formatted with the timestamp layout and timezone of the logger
+func (r *Record) Timestamp() string {
+	return formatTimestamp(r.Time, r.timestampLayout, r.location)
+}
+
+func formatTimestamp(t time.Time, layout string, location *time.Location) string {
+	if location != nil {
+		t = t.In(location)
+	}
+
+	switch layout {
+	case "":
+		return t.Format(TimestampDefault)
+	case TimestampRFC3339:
+		return t.Format(time.RFC3339)
+	case TimestampRFC3339Nano:
+		return t.Format(time.RFC3339Nano)
+	case TimestampUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimestampUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case TimestampUnixNano:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+func validateTimestampLayout(layout string) error {
+	if layout == "" || strings.Contains(layout, "%") {
+		return fmt.Errorf("invalid timestamp layout. The layout must not be empty or contain %%")
+	}
+	return nil
+}
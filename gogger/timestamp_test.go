@@ -0,0 +1,88 @@
+package gogger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("Timezone database is not available: %v", err)
+	}
+	timestamp := time.Date(2020, 9, 30, 21, 59, 5, 123456789, time.UTC)
+
+	tests := []struct {
+		layout   string
+		location *time.Location
+		expected string
+	}{
+		{"", time.UTC, "30-09-2020 21:59:05"},
+		{TimestampRFC3339, time.UTC, "2020-09-30T21:59:05Z"},
+		{TimestampRFC3339Nano, time.UTC, "2020-09-30T21:59:05.123456789Z"},
+		{TimestampRFC3339, moscow, "2020-10-01T00:59:05+03:00"},
+		{TimestampUnix, moscow, "1601503145"},
+		{TimestampUnixMilli, nil, "1601503145123"},
+		{TimestampUnixNano, nil, "1601503145123456789"},
+		{"2006-01-02 15:04:05.000", time.UTC, "2020-09-30 21:59:05.123"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTimestamp(timestamp, tt.layout, tt.location); got != tt.expected {
+			t.Errorf("formatTimestamp(%q) = %q, expected %q", tt.layout, got, tt.expected)
+		}
+	}
+}
+
+func TestSetTimestampFormat(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "%timestamp% | %timestamp:15:04% | %timestamp:unix% %message%", DEBUG)
+
+	if err := logger.SetTimestampFormat(TimestampRFC3339); err != nil {
+		t.Fatalf("SetTimestampFormat returned unexpected error: %v", err)
+	}
+	if err := logger.SetTimezone("UTC"); err != nil {
+		t.Fatalf("SetTimezone returned unexpected error: %v", err)
+	}
+
+	before := time.Now().Unix()
+	logger.Info("message")
+
+	var (
+		timestamp, clock string
+		unix             int64
+	)
+	if _, err := fmt.Sscanf(buf.String(), "%s | %s | %d message\n", &timestamp, &clock, &unix); err != nil {
+		t.Fatalf("Unexpected output %q: %v", buf.String(), err)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		t.Fatalf("Expected an RFC3339 timestamp, got %q", timestamp)
+	}
+	if parsed.Location() != time.UTC {
+		t.Errorf("Expected an UTC timestamp, got %q", timestamp)
+	}
+	if unix < before {
+		t.Errorf("Expected a Unix timestamp not before %d, got %d", before, unix)
+	}
+	if clock != parsed.Format("15:04") {
+		t.Errorf("Expected inline layout %q, got %q", parsed.Format("15:04"), clock)
+	}
+}
+
+func TestSetTimestampFormatValidation(t *testing.T) {
+	logger := newTestLogger(t, NewWriterSink(&bytes.Buffer{}))
+	defer logger.Close()
+
+	if err := logger.SetTimestampFormat(""); err == nil {
+		t.Error("SetTimestampFormat should return an error for an empty layout")
+	}
+	if err := logger.SetTimestampFormat("100%"); err == nil {
+		t.Error("SetTimestampFormat should return an error for a layout with %")
+	}
+	if err := logger.SetTimezone("Nowhere/Invalid"); err == nil {
+		t.Error("SetTimezone should return an error for an unknown timezone")
+	}
+}
@@ -0,0 +1,89 @@
+package gogger
+
+import (
+	"bytes"
+	"log"
+	"sync"
+)
+
+// LineWriter is an io.Writer that logs every written line as a separate record
+type LineWriter struct {
+	mu     sync.Mutex
+	logger *Gogger
+	level  LogLevel
+	buf    []byte
+}
+
+// Writer returns an io.Writer logging each line written to it at the level.
+// Incomplete lines are kept until the newline arrives or Flush is called
+func (l *Gogger) Writer(level LogLevel) *LineWriter {
+	return &LineWriter{logger: l, level: level}
+}
+
+// Write logs the complete lines of p and buffers the rest
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+
+	// Release the memory of the consumed lines
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+
+	return len(p), nil
+}
+
+// Flush logs the buffered incomplete line
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.logLine(w.buf)
+	w.buf = nil
+	return nil
+}
+
+func (w *LineWriter) logLine(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return
+	}
+	message := string(line)
+	w.logger.write(w.level, message, nil, bridgeSkip)
+	w.logger.terminate(w.level, message)
+}
+
+// StdLogger returns a standard library *log.Logger writing through the logger at the level
+func (l *Gogger) StdLogger(level LogLevel) *log.Logger {
+	return log.New(l.Writer(level), "", 0)
+}
+
+// RedirectStdLog sends the output of the standard library log package to the logger at the level.
+// The timestamp and prefix flags of the log package are cleared because Gogger adds its own.
+// The returned function restores the previous output, flags and prefix
+func (l *Gogger) RedirectStdLog(level LogLevel) func() {
+	std := log.Default()
+	previousOutput := std.Writer()
+	previousFlags := std.Flags()
+	previousPrefix := std.Prefix()
+
+	log.SetOutput(l.Writer(level))
+	log.SetFlags(0)
+	log.SetPrefix("")
+
+	return func() {
+		log.SetOutput(previousOutput)
+		log.SetFlags(previousFlags)
+		log.SetPrefix(previousPrefix)
+	}
+}
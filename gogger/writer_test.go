@@ -0,0 +1,57 @@
+package gogger
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "[%level%] %message%", DEBUG)
+	writer := logger.Writer(WARNING)
+
+	fmt.Fprint(writer, "first line\nsecond ")
+	fmt.Fprint(writer, "line\r\n\nthird")
+
+	if buf.String() != "[WARNING] first line\n[WARNING] second line\n" {
+		t.Errorf("Unexpected output before Flush: %q", buf.String())
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush returned unexpected error: %v", err)
+	}
+	if buf.String() != "[WARNING] first line\n[WARNING] second line\n[WARNING] third\n" {
+		t.Errorf("Unexpected output after Flush: %q", buf.String())
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "[%level%] %message%", DEBUG)
+
+	logger.StdLogger(ERROR).Printf("connection %d reset\nretrying", 7)
+
+	if buf.String() != "[ERROR] connection 7 reset\n[ERROR] retrying\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	logger, buf := newBufferTestLogger(t, "[%level%] %message%", DEBUG)
+
+	var previous bytes.Buffer
+	log.SetOutput(&previous)
+	defer log.SetOutput(log.Default().Writer())
+
+	restore := logger.RedirectStdLog(INFO)
+	log.Println("from the standard library")
+	restore()
+	log.Print("after restore")
+
+	if buf.String() != "[INFO] from the standard library\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+	if !bytes.Contains(previous.Bytes(), []byte("after restore")) {
+		t.Error("Previous output was not restored")
+	}
+}